@@ -0,0 +1,262 @@
+package bonusly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Achievement represents an achievement awarded to a user.
+type Achievement struct {
+	// Id of the achievement.
+	Id string `json:"id"`
+	// Title of the achievement.
+	Title string `json:"title"`
+	// Description of the achievement.
+	Description string `json:"description"`
+	// ImageUrl is the URL of the image of the achievement.
+	ImageUrl string `json:"image_url"`
+	// AwardedAt is the time the achievement was awarded.
+	AwardedAt time.Time `json:"awarded_at"`
+	// User is the user the achievement was awarded to.
+	User User `json:"user"`
+	// Bonus is the bonus that caused the achievement to be awarded. It is nil if the achievement was not awarded
+	// because of a bonus.
+	Bonus *Bonus `json:"bonus"`
+}
+
+// ListAchievementsInput represents the input of the "List Achievements" operation.
+type ListAchievementsInput struct {
+	// Limit is the maximum number of achievements to return.
+	Limit int
+	// Skip is the number of achievements to skip, used for pagination.
+	Skip int
+}
+
+// ListAchievementsOutput represents the output of the "List Achievements" operation.
+type ListAchievementsOutput struct {
+	// Achievements is a slice of all found achievements. If no achievements are found the slice will be empty.
+	Achievements []Achievement
+}
+
+// ListAchievementsPaginatorClient is the client interface required by the ListAchievementsPaginator.
+type ListAchievementsPaginatorClient interface {
+	ListAchievements(context.Context, *ListAchievementsInput) (*ListAchievementsOutput, error)
+}
+
+// ListAchievementsPaginator is a paginator for the "List Achievements" operation.
+type ListAchievementsPaginator struct {
+	client          ListAchievementsPaginatorClient
+	params          *ListAchievementsInput
+	firstPage       bool
+	offset          int
+	lastResultCount int
+}
+
+// NewListAchievementsPaginator returns a new ListAchievementsPaginator. If the limit of params is not set, a limit of
+// 20 achievements per page is used.
+func NewListAchievementsPaginator(client ListAchievementsPaginatorClient, params *ListAchievementsInput) *ListAchievementsPaginator {
+	if params == nil {
+		params = &ListAchievementsInput{}
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	return &ListAchievementsPaginator{
+		client:    client,
+		params:    params,
+		firstPage: true,
+	}
+}
+
+// HasMorePages returns true if more pages can be retrieved.
+func (p *ListAchievementsPaginator) HasMorePages() bool {
+	return p.firstPage || p.lastResultCount >= p.params.Limit
+}
+
+// NextPage retrieves the next page of achievements.
+func (p *ListAchievementsPaginator) NextPage(ctx context.Context) (*ListAchievementsOutput, error) {
+	p.firstPage = false
+	p.params.Skip = p.offset
+
+	output, err := p.client.ListAchievements(ctx, p.params)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lastResultCount = len(output.Achievements)
+	p.offset += p.lastResultCount
+
+	return output, nil
+}
+
+// ListAchievements returns a list of achievements awarded to the users of the company.
+//
+// The params parameter can be nil, which will cause the operation to use the default parameters for the operation.
+// To retrieve all achievements use the ListAchievementsPaginator.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/achievements/list-achievements
+func (c *Client) ListAchievements(ctx context.Context, params *ListAchievementsInput) (*ListAchievementsOutput, error) {
+	if params == nil {
+		params = &ListAchievementsInput{}
+	}
+
+	u, err := newListAchievementsURL(fmt.Sprintf("%s/achievements", c.endpoint), params.Limit, params.Skip)
+	if err != nil {
+		return nil, err
+	}
+
+	achievements, err := c.listAchievements(ctx, "list achievements", u)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListAchievementsOutput{Achievements: achievements}, nil
+}
+
+// ListUserAchievementsInput represents the input of the "Users Achievements" operation.
+type ListUserAchievementsInput struct {
+	// UserId is the id of the user to list the achievements for.
+	UserId string
+	// Limit is the maximum number of achievements to return.
+	Limit int
+	// Skip is the number of achievements to skip, used for pagination.
+	Skip int
+}
+
+// ListUserAchievementsOutput represents the output of the "Users Achievements" operation.
+type ListUserAchievementsOutput struct {
+	// Achievements is a slice of all found achievements. If no achievements are found the slice will be empty.
+	Achievements []Achievement
+}
+
+// ListUserAchievementsPaginatorClient is the client interface required by the ListUserAchievementsPaginator.
+type ListUserAchievementsPaginatorClient interface {
+	ListUserAchievements(context.Context, *ListUserAchievementsInput) (*ListUserAchievementsOutput, error)
+}
+
+// ListUserAchievementsPaginator is a paginator for the "Users Achievements" operation.
+type ListUserAchievementsPaginator struct {
+	client          ListUserAchievementsPaginatorClient
+	params          *ListUserAchievementsInput
+	firstPage       bool
+	offset          int
+	lastResultCount int
+}
+
+// NewListUserAchievementsPaginator returns a new ListUserAchievementsPaginator. The params must contain the id of the
+// user. If the limit of params is not set, a limit of 20 achievements per page is used.
+func NewListUserAchievementsPaginator(client ListUserAchievementsPaginatorClient, params *ListUserAchievementsInput) *ListUserAchievementsPaginator {
+	if params == nil {
+		params = &ListUserAchievementsInput{}
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	return &ListUserAchievementsPaginator{
+		client:    client,
+		params:    params,
+		firstPage: true,
+	}
+}
+
+// HasMorePages returns true if more pages can be retrieved.
+func (p *ListUserAchievementsPaginator) HasMorePages() bool {
+	return p.firstPage || p.lastResultCount >= p.params.Limit
+}
+
+// NextPage retrieves the next page of achievements.
+func (p *ListUserAchievementsPaginator) NextPage(ctx context.Context) (*ListUserAchievementsOutput, error) {
+	p.firstPage = false
+	p.params.Skip = p.offset
+
+	output, err := p.client.ListUserAchievements(ctx, p.params)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lastResultCount = len(output.Achievements)
+	p.offset += p.lastResultCount
+
+	return output, nil
+}
+
+// ListUserAchievements returns a list of achievements awarded to a single user.
+//
+// To retrieve all achievements of the user use the ListUserAchievementsPaginator.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/achievements
+func (c *Client) ListUserAchievements(ctx context.Context, params *ListUserAchievementsInput) (*ListUserAchievementsOutput, error) {
+	if params == nil || params.UserId == "" {
+		return nil, ErrMissingUserId
+	}
+
+	u, err := newListAchievementsURL(fmt.Sprintf("%s/users/%s/achievements", c.endpoint, params.UserId), params.Limit, params.Skip)
+	if err != nil {
+		return nil, err
+	}
+
+	achievements, err := c.listAchievements(ctx, "list user achievements", u)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUserAchievementsOutput{Achievements: achievements}, nil
+}
+
+// listAchievements sends the request for the given URL and returns the achievements of the response.
+func (c *Client) listAchievements(ctx context.Context, op string, u *url.URL) ([]Achievement, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result []Achievement `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse(op, resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Result, nil
+}
+
+// newListAchievementsURL returns the URL to get a list of achievements based on the provided base URL, limit and skip.
+// If the URL can not be created a non-nil error is returned and the URL is nil.
+func newListAchievementsURL(base string, limit int, skip int) (*url.URL, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(limit))
+	}
+
+	if skip > 0 {
+		q.Add("skip", strconv.Itoa(skip))
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u, nil
+}
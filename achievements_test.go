@@ -0,0 +1,169 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type mockListAchievementsClient struct {
+	pages []int
+	c     int
+}
+
+func (m *mockListAchievementsClient) ListAchievements(context.Context, *ListAchievementsInput) (*ListAchievementsOutput, error) {
+	p := m.pages[m.c]
+	m.c++
+
+	return &ListAchievementsOutput{Achievements: make([]Achievement, p)}, nil
+}
+
+func (m *mockListAchievementsClient) ListUserAchievements(_ context.Context, params *ListUserAchievementsInput) (*ListUserAchievementsOutput, error) {
+	if params.UserId == "" {
+		return nil, ErrMissingUserId
+	}
+
+	p := m.pages[m.c]
+	m.c++
+
+	return &ListUserAchievementsOutput{Achievements: make([]Achievement, p)}, nil
+}
+
+func TestListAchievementsPaginator(t *testing.T) {
+	client := &mockListAchievementsClient{pages: []int{20, 20, 1}}
+
+	var achievements []Achievement
+
+	paginator := NewListAchievementsPaginator(client, nil)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		achievements = append(achievements, output.Achievements...)
+	}
+
+	if len(achievements) != 41 {
+		t.Errorf("ListAchievementsPaginator(), got = %d, want = %d", len(achievements), 41)
+	}
+}
+
+func TestListAchievementsPaginator_DefaultLimit(t *testing.T) {
+	client := &mockListAchievementsClient{pages: []int{20, 3}}
+
+	var achievements []Achievement
+
+	paginator := NewListAchievementsPaginator(client, &ListAchievementsInput{})
+	for paginator.HasMorePages() {
+		if client.c >= len(client.pages) {
+			t.Fatalf("ListAchievementsPaginator() requested more than %d pages", len(client.pages))
+		}
+
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		achievements = append(achievements, output.Achievements...)
+	}
+
+	if len(achievements) != 23 {
+		t.Errorf("ListAchievementsPaginator(), got = %d, want = %d", len(achievements), 23)
+	}
+}
+
+func TestListUserAchievementsPaginator(t *testing.T) {
+	client := &mockListAchievementsClient{pages: []int{10, 10, 0}}
+
+	var achievements []Achievement
+
+	paginator := NewListUserAchievementsPaginator(client, &ListUserAchievementsInput{UserId: "1", Limit: 10})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		achievements = append(achievements, output.Achievements...)
+	}
+
+	if len(achievements) != 20 {
+		t.Errorf("ListUserAchievementsPaginator(), got = %d, want = %d", len(achievements), 20)
+	}
+}
+
+func TestClient_ListUserAchievements(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "/users/1/achievements?limit=5&skip=10"
+		if r.URL.String() != want {
+			t.Errorf("ListUserAchievements() URL = %v, want %v", r.URL, want)
+		}
+
+		_, _ = w.Write([]byte(`{
+			"success": true,
+			"result": [{
+				"id": "a1",
+				"title": "First Bonus",
+				"description": "Gave the first bonus",
+				"image_url": "https://example.com/badge.png",
+				"awarded_at": "2022-03-01T10:00:00Z",
+				"user": {"id": "1", "email": "frodo@example.com"},
+				"bonus": {"id": "b1", "amount": 10}
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	got, err := client.ListUserAchievements(context.TODO(), &ListUserAchievementsInput{UserId: "1", Limit: 5, Skip: 10})
+	if err != nil {
+		t.Fatalf("ListUserAchievements() error = %v", err)
+	}
+
+	if len(got.Achievements) != 1 {
+		t.Fatalf("ListUserAchievements() got %d achievements, want %d", len(got.Achievements), 1)
+	}
+
+	a := got.Achievements[0]
+	if a.Title != "First Bonus" || a.User.Email != "frodo@example.com" || a.Bonus == nil || a.Bonus.Id != "b1" {
+		t.Errorf("ListUserAchievements() got = %+v", a)
+	}
+	if !a.AwardedAt.Equal(time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("ListUserAchievements() AwardedAt = %v", a.AwardedAt)
+	}
+
+	_, err = client.ListUserAchievements(context.TODO(), &ListUserAchievementsInput{})
+	if !errors.Is(err, ErrMissingUserId) {
+		t.Errorf("ListUserAchievements() error = %v, want %v", err, ErrMissingUserId)
+	}
+}
+
+func Test_newListAchievementsURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		skip  int
+		want  string
+	}{
+		{"no-settings", 0, 0, fmt.Sprintf("%s/achievements", EndpointProduction)},
+		{"limit", 25, 0, fmt.Sprintf("%s/achievements?limit=25", EndpointProduction)},
+		{"skip", 0, 7, fmt.Sprintf("%s/achievements?skip=7", EndpointProduction)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newListAchievementsURL(fmt.Sprintf("%s/achievements", EndpointProduction), tt.limit, tt.skip)
+			if err != nil {
+				t.Fatalf("newListAchievementsURL() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("newListAchievementsURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package bonusly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TrendGranularity is the size of the time buckets of a trend.
+type TrendGranularity string
+
+const (
+	TrendGranularityDay   TrendGranularity = "day"
+	TrendGranularityWeek  TrendGranularity = "week"
+	TrendGranularityMonth TrendGranularity = "month"
+)
+
+// TrendMetric is the metric a trend is calculated for.
+type TrendMetric string
+
+const (
+	// TrendMetricGiven is the number of points given.
+	TrendMetricGiven TrendMetric = "given"
+	// TrendMetricReceived is the number of points received.
+	TrendMetricReceived TrendMetric = "received"
+	// TrendMetricParticipation is the percentage of users that gave or received a bonus.
+	TrendMetricParticipation TrendMetric = "participation"
+)
+
+// TrendGroupBy is the custom property a trend is grouped by.
+type TrendGroupBy string
+
+const (
+	TrendGroupByDepartment TrendGroupBy = "department"
+	TrendGroupByLocation   TrendGroupBy = "location"
+	TrendGroupByRole       TrendGroupBy = "role"
+)
+
+// GetTrendsInput represents the input of the "Trends" operation.
+type GetTrendsInput struct {
+	// StartTime is the start of the period (optional).
+	StartTime time.Time
+	// EndTime is the end of the period (optional).
+	EndTime time.Time
+	// Granularity is the size of the time buckets (optional).
+	Granularity TrendGranularity
+	// GroupBy groups the trend by a custom property (optional). If not set, the trend is calculated for the whole
+	// company.
+	GroupBy TrendGroupBy
+	// Metric is the metric the trend is calculated for (optional).
+	Metric TrendMetric
+}
+
+// GetTrendsOutput represents the output of the "Trends" operation.
+type GetTrendsOutput struct {
+	// Series contains one time series per group, sorted by group. If the trend is not grouped, it contains a single
+	// time series.
+	Series []TrendSeries
+}
+
+// TrendSeries is the time series of a trend for a single group.
+type TrendSeries struct {
+	// Group is the value of the custom property of the group, for example "marketing". If the trend is not grouped,
+	// the group is empty.
+	Group string
+	// Buckets are the time buckets of the series, sorted by time.
+	Buckets []TrendBucket
+}
+
+// TrendBucket is a single time bucket of a TrendSeries.
+type TrendBucket struct {
+	// Time is the start of the time bucket.
+	Time time.Time
+	// Value is the value of the metric in the time bucket.
+	Value float64
+}
+
+// GetTrends returns the trends of the company for the given period.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/analytics/trends
+func (c *Client) GetTrends(ctx context.Context, params *GetTrendsInput) (*GetTrendsOutput, error) {
+	if params == nil {
+		params = &GetTrendsInput{}
+	}
+
+	u, err := newGetTrendsURL(c.endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result json.RawMessage `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("get trends", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := newTrendSeries(r.Result, params.GroupBy != "")
+	if err != nil {
+		return nil, fmt.Errorf("get trends: %w", err)
+	}
+
+	return &GetTrendsOutput{Series: series}, nil
+}
+
+// newGetTrendsURL returns the URL to get the trends (GetTrends) based on the provided endpoint and params. If the URL
+// can not be created a non-nil error is returned and the URL is nil.
+func newGetTrendsURL(endpoint Endpoint, params *GetTrendsInput) (*url.URL, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/analytics/trends", endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+
+	addPeriod(q, params.StartTime, params.EndTime)
+
+	if params.Granularity != "" {
+		q.Add("granularity", string(params.Granularity))
+	}
+
+	if params.GroupBy != "" {
+		q.Add("group_by", string(params.GroupBy))
+	}
+
+	if params.Metric != "" {
+		q.Add("metric", string(params.Metric))
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u, nil
+}
+
+// addPeriod adds the start and end time of a period to the query. Zero times are not added.
+func addPeriod(q url.Values, start time.Time, end time.Time) {
+	if !start.IsZero() {
+		q.Add("start_time", start.Format(time.RFC3339))
+	}
+
+	if !end.IsZero() {
+		q.Add("end_time", end.Format(time.RFC3339))
+	}
+}
+
+// newTrendSeries converts the raw result of the "Trends" operation into time series sorted by group and time.
+//
+// If grouped is false, the result maps dates to values and a single series with an empty group is returned. If grouped
+// is true, the result maps groups to maps of dates and values.
+func newTrendSeries(result json.RawMessage, grouped bool) ([]TrendSeries, error) {
+	if !grouped {
+		var values map[string]float64
+		err := json.Unmarshal(result, &values)
+		if err != nil {
+			return nil, err
+		}
+
+		buckets, err := newTrendBuckets(values)
+		if err != nil {
+			return nil, err
+		}
+
+		return []TrendSeries{{Buckets: buckets}}, nil
+	}
+
+	var groups map[string]map[string]float64
+	err := json.Unmarshal(result, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]TrendSeries, 0, len(groups))
+	for group, values := range groups {
+		buckets, err := newTrendBuckets(values)
+		if err != nil {
+			return nil, err
+		}
+
+		series = append(series, TrendSeries{Group: group, Buckets: buckets})
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Group < series[j].Group
+	})
+
+	return series, nil
+}
+
+// newTrendBuckets converts a map of dates and values into time buckets sorted by time.
+func newTrendBuckets(values map[string]float64) ([]TrendBucket, error) {
+	buckets := make([]TrendBucket, 0, len(values))
+
+	for key, value := range values {
+		t, err := parseTrendTime(key)
+		if err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, TrendBucket{Time: t, Value: value})
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Time.Before(buckets[j].Time)
+	})
+
+	return buckets, nil
+}
+
+// parseTrendTime parses the time of a trend bucket, which is either a date ("2006-01-02") or a RFC3339 timestamp.
+func parseTrendTime(value string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", value)
+	if err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// LeaderboardType defines whether a leaderboard ranks users by the bonuses they gave or received.
+type LeaderboardType string
+
+const (
+	LeaderboardTypeGivers    LeaderboardType = "giver"
+	LeaderboardTypeReceivers LeaderboardType = "receiver"
+)
+
+// GetLeaderboardInput represents the input of the "Leaderboards" operation.
+type GetLeaderboardInput struct {
+	// Type of the leaderboard (default: LeaderboardTypeReceivers).
+	Type LeaderboardType
+	// StartTime is the start of the period (optional).
+	StartTime time.Time
+	// EndTime is the end of the period (optional).
+	EndTime time.Time
+	// Limit is the maximum number of entries to return (optional).
+	Limit int
+	// CustomPropertyName limits the leaderboard to users with the custom property, for example
+	// "department=marketing" (optional).
+	CustomPropertyName string
+}
+
+// GetLeaderboardOutput represents the output of the "Leaderboards" operation.
+type GetLeaderboardOutput struct {
+	// Entries of the leaderboard, sorted by rank.
+	Entries []LeaderboardEntry
+}
+
+// LeaderboardEntry is a single entry of a leaderboard.
+type LeaderboardEntry struct {
+	// Rank of the user, starting at 1.
+	Rank int
+	// User is the ranked user.
+	User User
+	// Count is the number of bonuses the user gave or received.
+	Count int
+	// Points is the total number of points the user gave or received.
+	Points int
+}
+
+// GetLeaderboard returns the leaderboard of the company for the given period.
+//
+// The leaderboard is read from the "Leaderboards" index of the Analytics section of the API reference
+// (GET /analytics/standouts).
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/analytics/leaderboards
+func (c *Client) GetLeaderboard(ctx context.Context, params *GetLeaderboardInput) (*GetLeaderboardOutput, error) {
+	if params == nil {
+		params = &GetLeaderboardInput{}
+	}
+
+	u, err := newGetLeaderboardURL(c.endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result []struct {
+			User   User `json:"user"`
+			Count  int  `json:"count"`
+			Points int  `json:"points"`
+		} `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("get leaderboard", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]LeaderboardEntry, len(r.Result))
+	for i := range r.Result {
+		entries[i] = LeaderboardEntry{
+			Rank:   i + 1,
+			User:   r.Result[i].User,
+			Count:  r.Result[i].Count,
+			Points: r.Result[i].Points,
+		}
+	}
+
+	return &GetLeaderboardOutput{Entries: entries}, nil
+}
+
+// newGetLeaderboardURL returns the URL to get a leaderboard (GetLeaderboard) based on the provided endpoint and params.
+// If the URL can not be created a non-nil error is returned and the URL is nil.
+func newGetLeaderboardURL(endpoint Endpoint, params *GetLeaderboardInput) (*url.URL, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/analytics/standouts", endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+
+	role := params.Type
+	if role == "" {
+		role = LeaderboardTypeReceivers
+	}
+	q.Add("role", string(role))
+
+	addPeriod(q, params.StartTime, params.EndTime)
+
+	if params.Limit > 0 {
+		q.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	if params.CustomPropertyName != "" {
+		q.Add("custom_property_name", params.CustomPropertyName)
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u, nil
+}
@@ -0,0 +1,245 @@
+package bonusly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_newGetTrendsURL(t *testing.T) {
+	type args struct {
+		params *GetTrendsInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *url.URL
+		wantErr bool
+	}{
+		{
+			"no-settings",
+			args{params: &GetTrendsInput{}},
+			mustURL(t, fmt.Sprintf("%s/analytics/trends", EndpointProduction)),
+			false,
+		},
+		{
+			"period",
+			args{params: &GetTrendsInput{
+				StartTime: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC),
+			}},
+			mustURL(t, fmt.Sprintf("%s/analytics/trends?end_time=%s&start_time=%s", EndpointProduction,
+				url.QueryEscape("2022-03-31T00:00:00Z"), url.QueryEscape("2022-01-01T00:00:00Z"))),
+			false,
+		},
+		{
+			"all-settings",
+			args{params: &GetTrendsInput{
+				Granularity: TrendGranularityWeek,
+				GroupBy:     TrendGroupByDepartment,
+				Metric:      TrendMetricParticipation,
+			}},
+			mustURL(t, fmt.Sprintf("%s/analytics/trends?granularity=week&group_by=department&metric=participation", EndpointProduction)),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newGetTrendsURL(EndpointProduction, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newGetTrendsURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("newGetTrendsURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newTrendSeries(t *testing.T) {
+	jan := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		result  string
+		grouped bool
+		want    []TrendSeries
+		wantErr bool
+	}{
+		{
+			"not-grouped",
+			`{"2022-02-01": 20, "2022-01-01": 10}`,
+			false,
+			[]TrendSeries{{Buckets: []TrendBucket{{jan, 10}, {feb, 20}}}},
+			false,
+		},
+		{
+			"grouped",
+			`{"sales": {"2022-01-01T00:00:00Z": 3}, "marketing": {"2022-02-01": 7, "2022-01-01": 5}}`,
+			true,
+			[]TrendSeries{
+				{Group: "marketing", Buckets: []TrendBucket{{jan, 5}, {feb, 7}}},
+				{Group: "sales", Buckets: []TrendBucket{{jan, 3}}},
+			},
+			false,
+		},
+		{
+			"grouped-shape-not-grouped",
+			`{"sales": {"2022-01-01": 3}}`,
+			false,
+			nil,
+			true,
+		},
+		{
+			"invalid-time",
+			`{"January": 1}`,
+			false,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTrendSeries(json.RawMessage(tt.result), tt.grouped)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newTrendSeries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newTrendSeries() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetTrends(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/analytics/trends" {
+			t.Errorf("GetTrends() URL = %v", r.URL)
+		}
+
+		if r.URL.Query().Get("group_by") == "" {
+			_, _ = w.Write([]byte(`{"success": true, "result": {"2022-01-01": 10, "2022-02-01": 20}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"success": true, "result": {"sales": {"2022-01-01": 3}, "marketing": {"2022-01-01": 5}}}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	tests := []struct {
+		name   string
+		params *GetTrendsInput
+		groups []string
+	}{
+		{"not-grouped", nil, []string{""}},
+		{"grouped", &GetTrendsInput{GroupBy: TrendGroupByDepartment}, []string{"marketing", "sales"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetTrends(context.TODO(), tt.params)
+			if err != nil {
+				t.Fatalf("GetTrends() error = %v", err)
+			}
+
+			var groups []string
+			for _, s := range got.Series {
+				groups = append(groups, s.Group)
+			}
+
+			if !reflect.DeepEqual(groups, tt.groups) {
+				t.Errorf("GetTrends() groups = %q, want %q", groups, tt.groups)
+			}
+		})
+	}
+}
+
+func Test_newGetLeaderboardURL(t *testing.T) {
+	type args struct {
+		params *GetLeaderboardInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *url.URL
+		wantErr bool
+	}{
+		{
+			"default-type",
+			args{params: &GetLeaderboardInput{}},
+			mustURL(t, fmt.Sprintf("%s/analytics/standouts?role=receiver", EndpointProduction)),
+			false,
+		},
+		{
+			"givers",
+			args{params: &GetLeaderboardInput{Type: LeaderboardTypeGivers, Limit: 10}},
+			mustURL(t, fmt.Sprintf("%s/analytics/standouts?limit=10&role=giver", EndpointProduction)),
+			false,
+		},
+		{
+			"period-custom-property",
+			args{params: &GetLeaderboardInput{
+				StartTime:          time.Date(2022, 3, 7, 0, 0, 0, 0, time.UTC),
+				CustomPropertyName: "department=marketing",
+			}},
+			mustURL(t, fmt.Sprintf("%s/analytics/standouts?custom_property_name=%s&role=receiver&start_time=%s", EndpointProduction,
+				url.QueryEscape("department=marketing"), url.QueryEscape("2022-03-07T00:00:00Z"))),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newGetLeaderboardURL(EndpointProduction, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newGetLeaderboardURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("newGetLeaderboardURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetLeaderboard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/analytics/standouts" || r.URL.Query().Get("role") != "giver" {
+			t.Errorf("GetLeaderboard() URL = %v", r.URL)
+		}
+
+		_, _ = w.Write([]byte(`{
+			"success": true,
+			"result": [
+				{"user": {"id": "1", "email": "frodo@example.com"}, "count": 12, "points": 120},
+				{"user": {"id": "2", "email": "bilbo@example.com"}, "count": 8, "points": 95}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	got, err := client.GetLeaderboard(context.TODO(), &GetLeaderboardInput{Type: LeaderboardTypeGivers})
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+
+	if len(got.Entries) != 2 {
+		t.Fatalf("GetLeaderboard() got %d entries, want %d", len(got.Entries), 2)
+	}
+
+	second := got.Entries[1]
+	if second.Rank != 2 || second.User.Email != "bilbo@example.com" || second.Count != 8 || second.Points != 95 {
+		t.Errorf("GetLeaderboard() got = %+v", second)
+	}
+}
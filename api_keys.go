@@ -0,0 +1,196 @@
+package bonusly
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// APIKeyAccessLevel is the access level of an API key.
+type APIKeyAccessLevel string
+
+const (
+	// APIKeyAccessLevelRead allows read-only access.
+	APIKeyAccessLevelRead APIKeyAccessLevel = "read"
+	// APIKeyAccessLevelWrite allows read and write access.
+	APIKeyAccessLevelWrite APIKeyAccessLevel = "write"
+	// APIKeyAccessLevelAdmin allows read and write access, including admin operations.
+	APIKeyAccessLevelAdmin APIKeyAccessLevel = "admin"
+	// APIKeyAccessLevelUnknown is reported by TokenInfo if the access level of the token could not be determined.
+	APIKeyAccessLevelUnknown APIKeyAccessLevel = "unknown"
+)
+
+var (
+	ErrMissingAPIKeyId = errors.New("missing api key id")
+
+	// ErrInvalidAPIKeyAccessLevel is returned by CreateAPIKey if the access level is not APIKeyAccessLevelRead,
+	// APIKeyAccessLevelWrite or APIKeyAccessLevelAdmin.
+	ErrInvalidAPIKeyAccessLevel = errors.New("invalid api key access level")
+)
+
+// APIKey represents a Bonus.ly API key. The secret of the API key is only available when the key is created.
+type APIKey struct {
+	// Id of the API key.
+	Id string `json:"id"`
+	// Label of the API key, describing what the key is used for.
+	Label string `json:"label"`
+	// Scope is the access level of the API key.
+	Scope APIKeyAccessLevel `json:"scope"`
+	// CreatedAt is the time the API key was created.
+	CreatedAt time.Time `json:"created_at"`
+	// LastUsedAt is the time the API key was last used. It is the zero time if the API key was never used.
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// ListAPIKeysOutput represents the output of the "List API Keys" operation.
+type ListAPIKeysOutput struct {
+	// APIKeys is a slice of all API keys. If no API keys are found the slice will be empty.
+	APIKeys []APIKey
+}
+
+// ListAPIKeys returns all API keys of the token owner.
+//
+// Note: The Bonus.ly API does not support pagination for this API. Therefore, no paginator exists.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/api-keys/list-api-keys
+func (c *Client) ListAPIKeys(ctx context.Context) (*ListAPIKeysOutput, error) {
+	u := fmt.Sprintf("%s/api_keys", c.endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result []APIKey `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("list api keys", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListAPIKeysOutput{APIKeys: r.Result}, nil
+}
+
+// CreateAPIKeyInput represents the input of the "Create API Key" operation.
+type CreateAPIKeyInput struct {
+	// Label of the API key, describing what the key is used for.
+	Label string
+	// AccessLevel of the API key. It must be APIKeyAccessLevelRead, APIKeyAccessLevelWrite or APIKeyAccessLevelAdmin.
+	AccessLevel APIKeyAccessLevel
+}
+
+// CreateAPIKeyOutput represents the output of the "Create API Key" operation.
+type CreateAPIKeyOutput struct {
+	// APIKey is the created API key.
+	APIKey APIKey
+	// Secret is the token of the created API key, used to authenticate requests. The secret can not be retrieved
+	// again later, so it must be stored safely.
+	Secret string
+}
+
+type createAPIKeyBody struct {
+	Label       string            `json:"label"`
+	AccessLevel APIKeyAccessLevel `json:"access_level"`
+}
+
+// CreateAPIKey creates a new API key.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/api-keys/create-api-key
+func (c *Client) CreateAPIKey(ctx context.Context, params *CreateAPIKeyInput) (*CreateAPIKeyOutput, error) {
+	if params == nil {
+		return nil, ErrMissingParams
+	}
+
+	switch params.AccessLevel {
+	case APIKeyAccessLevelRead, APIKeyAccessLevelWrite, APIKeyAccessLevelAdmin:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAPIKeyAccessLevel, params.AccessLevel)
+	}
+
+	b, err := json.Marshal(createAPIKeyBody{Label: params.Label, AccessLevel: params.AccessLevel})
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/api_keys", c.endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result struct {
+			APIKey
+
+			Token string `json:"token"`
+		} `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("create api key", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateAPIKeyOutput{APIKey: r.Result.APIKey, Secret: r.Result.Token}, nil
+}
+
+// CancelAPIKeyInput represents the input of the "Cancel API Key" operation.
+type CancelAPIKeyInput struct {
+	// Id of the API key to cancel.
+	Id string
+}
+
+// CancelAPIKeyOutput represents the output of the "Cancel API Key" operation.
+type CancelAPIKeyOutput struct {
+	// Id of the canceled API key.
+	Id string
+}
+
+// CancelAPIKey cancels an API key. Requests using the canceled API key are rejected afterwards.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/api-keys/cancel-api-key
+func (c *Client) CancelAPIKey(ctx context.Context, params *CancelAPIKeyInput) (*CancelAPIKeyOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingAPIKeyId
+	}
+
+	u := fmt.Sprintf("%s/api_keys/%s", c.endpoint, params.Id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var r baseAPIResponse
+	err = decodeResponse("cancel api key", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CancelAPIKeyOutput{Id: params.Id}, nil
+}
@@ -0,0 +1,75 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_APIKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api_keys":
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": "k1", "label": "HR Bot", "scope": "write", "created_at": "2022-03-01T10:00:00Z", "last_used_at": null}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api_keys":
+			body, _ := ioutil.ReadAll(r.Body)
+			if string(body) != `{"label":"Report","access_level":"read"}` {
+				t.Errorf("CreateAPIKey() body = %s", body)
+			}
+
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": "k2", "label": "Report", "scope": "read", "token": "secret"}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api_keys/k1":
+			_, _ = w.Write([]byte(`{"success": true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	list, err := client.ListAPIKeys(context.TODO())
+	if err != nil {
+		t.Fatalf("ListAPIKeys() error = %v", err)
+	}
+	if len(list.APIKeys) != 1 || list.APIKeys[0].Scope != APIKeyAccessLevelWrite || !list.APIKeys[0].LastUsedAt.IsZero() ||
+		!list.APIKeys[0].CreatedAt.Equal(time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("ListAPIKeys() got = %+v", list.APIKeys)
+	}
+
+	created, err := client.CreateAPIKey(context.TODO(), &CreateAPIKeyInput{Label: "Report", AccessLevel: APIKeyAccessLevelRead})
+	if err != nil {
+		t.Fatalf("CreateAPIKey() error = %v", err)
+	}
+	if created.APIKey.Id != "k2" || created.Secret != "secret" {
+		t.Errorf("CreateAPIKey() got = %+v", created)
+	}
+
+	for _, level := range []APIKeyAccessLevel{"", APIKeyAccessLevelUnknown, "owner"} {
+		_, err = client.CreateAPIKey(context.TODO(), &CreateAPIKeyInput{Label: "Report", AccessLevel: level})
+		if !errors.Is(err, ErrInvalidAPIKeyAccessLevel) {
+			t.Errorf("CreateAPIKey(%q) error = %v, want %v", level, err, ErrInvalidAPIKeyAccessLevel)
+		}
+	}
+
+	if _, err = client.CreateAPIKey(context.TODO(), nil); !errors.Is(err, ErrMissingParams) {
+		t.Errorf("CreateAPIKey() error = %v, want %v", err, ErrMissingParams)
+	}
+
+	canceled, err := client.CancelAPIKey(context.TODO(), &CancelAPIKeyInput{Id: "k1"})
+	if err != nil {
+		t.Fatalf("CancelAPIKey() error = %v", err)
+	}
+	if canceled.Id != "k1" {
+		t.Errorf("CancelAPIKey() got = %v, want %v", canceled.Id, "k1")
+	}
+
+	_, err = client.CancelAPIKey(context.TODO(), &CancelAPIKeyInput{Id: "unknown"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("CancelAPIKey() error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -4,41 +4,133 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Bonus represents a Bonus.ly bonus.
+type Bonus struct {
+	// Id of the bonus.
+	Id string `json:"id"`
+	// CreatedAt is the time the bonus was created.
+	CreatedAt time.Time `json:"created_at"`
+	// Reason is the reason of the bonus as plain text, including amount, receivers and hashtags.
+	Reason string `json:"reason"`
+	// ReasonHTML is the reason of the bonus formatted as HTML.
+	ReasonHTML string `json:"reason_html"`
+	// Amount is the number of points given to every receiver of the bonus.
+	Amount int `json:"amount"`
+	// AmountWithCurrency is the amount of the bonus including the company currency, for example "10 points".
+	AmountWithCurrency string `json:"amount_with_currency"`
+	// FamilyAmount is the total amount of the bonus, including all add-on bonuses (child bonuses).
+	FamilyAmount int `json:"family_amount"`
+	// Hashtag is the hashtag (company value) of the bonus, for example "#teamwork".
+	Hashtag string `json:"hashtag"`
+	// Value is the company value of the bonus without the leading "#", for example "teamwork".
+	Value string `json:"value"`
+	// Via is the name of the application that was used to create the bonus.
+	Via string `json:"via"`
+	// Giver is the user that gave the bonus.
+	Giver User `json:"giver"`
+	// Receivers are the users that received the bonus.
+	Receivers []User `json:"receivers"`
+	// ParentBonusId is the id of the parent bonus if the bonus is an add-on bonus. Otherwise, it is empty.
+	ParentBonusId string `json:"parent_bonus_id"`
+	// ChildCount is the number of add-on bonuses (child bonuses) of the bonus.
+	ChildCount int `json:"child_count"`
+	// ChildBonuses are the add-on bonuses of the bonus.
+	ChildBonuses []Bonus `json:"child_bonuses"`
+}
+
+// BonusFormat defines how the "Create a Bonus" operation sends a bonus to the Bonus.ly REST API.
+type BonusFormat int
+
+const (
+	// BonusFormatReason sends the bonus as a single reason string, for example "+10 @receiver for #teamwork". The
+	// reason string is created by the ReasonFormatter of the Client and parsed by the Bonus.ly REST API.
+	BonusFormatReason BonusFormat = iota
+	// BonusFormatStructured sends the receivers, the amount, the hashtag and the reason as separate fields. The
+	// reason is sent as-is, so "@" or "+" characters in the reason are not interpreted as receivers or amount.
+	BonusFormatStructured
+)
+
+// ReasonFormatter creates the reason string of a bonus from the CreateBonusInput. It is used by the "Create a Bonus"
+// operation if the BonusFormatReason format is used.
+type ReasonFormatter func(params *CreateBonusInput) string
+
+// WithReasonFormatter sets the ReasonFormatter used by the bonusly.Client to create the reason string of a bonus.
+//
+// The default formatter creates reason strings like "+10 @receiver1 @receiver2 reason".
+func WithReasonFormatter(formatter ReasonFormatter) ClientOption {
+	return func(c *Client) {
+		c.reasonFormatter = formatter
+	}
+}
+
+// CreateBonusInput represents the input of the "Create a Bonus" operation.
 type CreateBonusInput struct {
-	GiverEmail    string
-	Receivers     []string
-	Reason        string
-	Amount        uint
+	// GiverEmail is the email of the user giving the bonus. Requires an admin token if it is not the token owner.
+	GiverEmail string
+	// Receivers of the bonus. Usernames or emails if the BonusFormatReason format is used, emails if the
+	// BonusFormatStructured format is used.
+	Receivers []string
+	// Reason of the bonus.
+	Reason string
+	// Amount of points every receiver gets.
+	Amount uint
+	// Hashtags (company values) of the bonus, for example "#teamwork". Hashtags are normalized, so "teamwork" and
+	// "#team work" are sent as "#teamwork".
+	Hashtags []string
+	// ParentBonusID is the id of the parent bonus to create an add-on bonus (optional).
 	ParentBonusID string
+	// Format defines how the bonus is sent to the Bonus.ly REST API (default: BonusFormatReason).
+	Format BonusFormat
 }
 
 type createBonusBody struct {
 	GiverEmail    string `json:"giver_email"`
 	Reason        string `json:"reason"`
+	ReceiverEmail string `json:"receiver_email,omitempty"`
+	Amount        uint   `json:"amount,omitempty"`
+	Hashtag       string `json:"hashtag,omitempty"`
 	ParentBonusID string `json:"parent_bonus_id,omitempty"`
 }
 
+// CreateBonusOutput represents the output of the "Create a Bonus" operation.
 type CreateBonusOutput struct {
+	// Bonus is the created bonus, including its id, the resolved receivers, the parsed amount and hashtag and the id
+	// of the parent bonus if the bonus is an add-on bonus.
+	Bonus Bonus
 }
 
 type createBonusResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
+	baseAPIResponse
+
+	Result Bonus `json:"result"`
 }
 
+// CreateBonus creates a new bonus. This operation requires a token with write access.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/bonuses/create-a-bonus
 func (c *Client) CreateBonus(ctx context.Context, params *CreateBonusInput) (*CreateBonusOutput, error) {
-	// TODO: Add option that allows overwriting the formatting of the reason string.
-	b := createBonusBody{
-		GiverEmail:    params.GiverEmail,
-		Reason:        newReason(params),
-		ParentBonusID: params.ParentBonusID,
+	if params == nil {
+		return nil, ErrMissingParams
+	}
+
+	if c.validateHashtags && len(params.Hashtags) > 0 {
+		err := c.ValidateHashtags(ctx, params.Hashtags)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	b := newCreateBonusBody(params, c.reasonFormatter)
+
 	body, err := json.Marshal(b)
 	if err != nil {
 		return nil, err
@@ -59,24 +151,59 @@ func (c *Client) CreateBonus(ctx context.Context, params *CreateBonusInput) (*Cr
 		return nil, err
 	}
 
-	respBody, err := readAndCloseBody(resp)
+	var r createBonusResponse
+	err = decodeResponse("create bonus", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	var r createBonusResponse
-	err = json.Unmarshal(respBody, &r)
-	if err != nil {
-		return nil, err
+	return &CreateBonusOutput{Bonus: r.Result}, nil
+}
+
+// newCreateBonusBody returns the request body of the "Create a Bonus" operation based on the format of the params. The
+// formatter is used to create the reason string for the BonusFormatReason format. If it is nil, newReason is used.
+//
+// The hashtags of the params are normalized before the body is created. For the BonusFormatStructured format the first
+// hashtag is sent in the hashtag field and all other hashtags are appended to the reason.
+func newCreateBonusBody(params *CreateBonusInput, formatter ReasonFormatter) createBonusBody {
+	p := *params
+	p.Hashtags = normalizeHashtags(params.Hashtags)
+
+	if p.Format == BonusFormatStructured {
+		receivers := make([]string, len(p.Receivers))
+		for i := range p.Receivers {
+			receivers[i] = strings.TrimSpace(p.Receivers[i])
+		}
+
+		var hashtag string
+		reason := p.Reason
+		if len(p.Hashtags) > 0 {
+			hashtag = p.Hashtags[0]
+			reason = appendHashtags(reason, p.Hashtags[1:])
+		}
+
+		return createBonusBody{
+			GiverEmail:    p.GiverEmail,
+			Reason:        reason,
+			ReceiverEmail: strings.Join(receivers, ","),
+			Amount:        p.Amount,
+			Hashtag:       hashtag,
+			ParentBonusID: p.ParentBonusID,
+		}
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("create bonus: %s", r.Message)
+	if formatter == nil {
+		formatter = newReason
 	}
 
-	return &CreateBonusOutput{}, nil
+	return createBonusBody{
+		GiverEmail:    p.GiverEmail,
+		Reason:        formatter(&p),
+		ParentBonusID: p.ParentBonusID,
+	}
 }
 
+// newReason is the default ReasonFormatter. It creates reason strings like "+10 @receiver1 @receiver2 reason #hashtag".
 func newReason(params *CreateBonusInput) string {
 	// string builder is used because the receivers list could potentially contain >50 users.
 	receivers := strings.Builder{}
@@ -86,5 +213,499 @@ func newReason(params *CreateBonusInput) string {
 		receivers.WriteString(" ")
 	}
 
-	return fmt.Sprintf("+%d %s %s", params.Amount, strings.TrimSpace(receivers.String()), params.Reason)
+	reason := appendHashtags(params.Reason, params.Hashtags)
+
+	return fmt.Sprintf("+%d %s %s", params.Amount, strings.TrimSpace(receivers.String()), reason)
+}
+
+// ListBonusesInput represents the input of the "List Bonuses" operation.
+type ListBonusesInput struct {
+	// Limit is the maximum number of bonuses to return (maximum: 100).
+	Limit int
+	// Skip is the number of bonuses to skip, used for pagination.
+	Skip int
+	// StartTime limits the result to bonuses created after the start time (optional).
+	StartTime time.Time
+	// EndTime limits the result to bonuses created before the end time (optional).
+	EndTime time.Time
+	// GiverEmail limits the result to bonuses given by the user with the email (optional).
+	GiverEmail string
+	// ReceiverEmail limits the result to bonuses received by the user with the email (optional).
+	ReceiverEmail string
+	// UserEmail limits the result to bonuses given or received by the user with the email (optional).
+	UserEmail string
+	// Hashtag limits the result to bonuses with the hashtag, for example "#teamwork" (optional).
+	Hashtag string
+	// IncludeChildren includes add-on bonuses (child bonuses) in the result.
+	IncludeChildren bool
+	// CustomPropertyName limits the result to bonuses of users with the custom property, for example
+	// "department=marketing" (optional).
+	CustomPropertyName string
+	// ShowPrivateBonuses includes private bonuses in the result. Requires an admin token.
+	ShowPrivateBonuses bool
+}
+
+// ListBonusesOutput represents the output of the "List Bonuses" operation.
+type ListBonusesOutput struct {
+	// Bonuses is a slice of all found bonuses. If no bonuses are found the slice will be empty.
+	Bonuses []Bonus
+}
+
+// ListBonusesPaginatorClient is the client interface required by the ListBonusesPaginator.
+type ListBonusesPaginatorClient interface {
+	ListBonuses(context.Context, *ListBonusesInput) (*ListBonusesOutput, error)
+}
+
+// ListBonusesPaginator is a paginator for the "List Bonuses" operation.
+type ListBonusesPaginator struct {
+	client          ListBonusesPaginatorClient
+	params          *ListBonusesInput
+	firstPage       bool
+	offset          int
+	lastResultCount int
+}
+
+// NewListBonusesPaginator returns a new ListBonusesPaginator. If the limit of params is not set, a limit of 20 bonuses
+// per page is used.
+func NewListBonusesPaginator(client ListBonusesPaginatorClient, params *ListBonusesInput) *ListBonusesPaginator {
+	if params == nil {
+		params = &ListBonusesInput{}
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	return &ListBonusesPaginator{
+		client:    client,
+		params:    params,
+		firstPage: true,
+	}
+}
+
+// HasMorePages returns true if more pages can be retrieved.
+func (p *ListBonusesPaginator) HasMorePages() bool {
+	return p.firstPage || p.lastResultCount >= p.params.Limit
+}
+
+// NextPage retrieves the next page of bonuses.
+func (p *ListBonusesPaginator) NextPage(ctx context.Context) (*ListBonusesOutput, error) {
+	p.firstPage = false
+	p.params.Skip = p.offset
+
+	output, err := p.client.ListBonuses(ctx, p.params)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lastResultCount = len(output.Bonuses)
+	p.offset += p.lastResultCount
+
+	return output, nil
+}
+
+// ListBonuses returns a list of bonuses.
+//
+// The params parameter can be nil, which will cause the operation to use the default parameters for the operation.
+// To retrieve all bonuses use the ListBonusesPaginator.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/bonuses/list-bonuses
+func (c *Client) ListBonuses(ctx context.Context, params *ListBonusesInput) (*ListBonusesOutput, error) {
+	if params == nil {
+		params = &ListBonusesInput{}
+	}
+
+	u, err := newListBonusesURL(c.endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result []Bonus `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("list bonuses", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListBonusesOutput{Bonuses: r.Result}, nil
+}
+
+// newListBonusesURL returns the URL to get a list of bonuses (ListBonuses) based on the provided endpoint and params.
+// If the URL can not be created a non-nil error is returned and the URL is nil.
+//nolint: cyclop
+func newListBonusesURL(endpoint Endpoint, params *ListBonusesInput) (*url.URL, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/bonuses", endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+
+	if params.Limit > 0 {
+		q.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	if params.Skip > 0 {
+		q.Add("skip", strconv.Itoa(params.Skip))
+	}
+
+	if !params.StartTime.IsZero() {
+		q.Add("start_time", params.StartTime.Format(time.RFC3339))
+	}
+
+	if !params.EndTime.IsZero() {
+		q.Add("end_time", params.EndTime.Format(time.RFC3339))
+	}
+
+	if params.GiverEmail != "" {
+		q.Add("giver_email", params.GiverEmail)
+	}
+
+	if params.ReceiverEmail != "" {
+		q.Add("receiver_email", params.ReceiverEmail)
+	}
+
+	if params.UserEmail != "" {
+		q.Add("user_email", params.UserEmail)
+	}
+
+	if params.Hashtag != "" {
+		q.Add("hashtag", params.Hashtag)
+	}
+
+	if params.IncludeChildren {
+		q.Add("include_children", "true")
+	}
+
+	if params.CustomPropertyName != "" {
+		q.Add("custom_property_name", params.CustomPropertyName)
+	}
+
+	if params.ShowPrivateBonuses {
+		q.Add("show_private_bonuses", "true")
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u, nil
+}
+
+// UserBonusRole defines whether the bonuses of a user are the bonuses the user gave or received.
+type UserBonusRole string
+
+const (
+	// UserBonusRoleGiven limits the result to bonuses given by the user.
+	UserBonusRoleGiven UserBonusRole = "given"
+	// UserBonusRoleReceived limits the result to bonuses received by the user.
+	UserBonusRoleReceived UserBonusRole = "received"
+)
+
+// ListUserBonusesInput represents the input of the "Users Bonuses" operation.
+type ListUserBonusesInput struct {
+	// UserId is the id of the user to list the bonuses for.
+	UserId string
+	// Role limits the result to bonuses given or received by the user (optional). If not set, bonuses given and
+	// received by the user are returned.
+	Role UserBonusRole
+	// Limit is the maximum number of bonuses to return (maximum: 100).
+	Limit int
+	// Skip is the number of bonuses to skip, used for pagination.
+	Skip int
+}
+
+// ListUserBonusesOutput represents the output of the "Users Bonuses" operation.
+type ListUserBonusesOutput struct {
+	// Bonuses is a slice of all found bonuses. If no bonuses are found the slice will be empty.
+	Bonuses []Bonus
+}
+
+// ListUserBonusesPaginatorClient is the client interface required by the ListUserBonusesPaginator.
+type ListUserBonusesPaginatorClient interface {
+	ListUserBonuses(context.Context, *ListUserBonusesInput) (*ListUserBonusesOutput, error)
+}
+
+// ListUserBonusesPaginator is a paginator for the "Users Bonuses" operation.
+type ListUserBonusesPaginator struct {
+	client          ListUserBonusesPaginatorClient
+	params          *ListUserBonusesInput
+	firstPage       bool
+	offset          int
+	lastResultCount int
+}
+
+// NewListUserBonusesPaginator returns a new ListUserBonusesPaginator. The params must contain the id of the user. If
+// the limit of params is not set, a limit of 20 bonuses per page is used.
+func NewListUserBonusesPaginator(client ListUserBonusesPaginatorClient, params *ListUserBonusesInput) *ListUserBonusesPaginator {
+	if params == nil {
+		params = &ListUserBonusesInput{}
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	return &ListUserBonusesPaginator{
+		client:    client,
+		params:    params,
+		firstPage: true,
+	}
+}
+
+// HasMorePages returns true if more pages can be retrieved.
+func (p *ListUserBonusesPaginator) HasMorePages() bool {
+	return p.firstPage || p.lastResultCount >= p.params.Limit
+}
+
+// NextPage retrieves the next page of bonuses.
+func (p *ListUserBonusesPaginator) NextPage(ctx context.Context) (*ListUserBonusesOutput, error) {
+	p.firstPage = false
+	p.params.Skip = p.offset
+
+	output, err := p.client.ListUserBonuses(ctx, p.params)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lastResultCount = len(output.Bonuses)
+	p.offset += p.lastResultCount
+
+	return output, nil
+}
+
+// ListUserBonuses returns a list of bonuses given or received by a single user.
+//
+// To retrieve all bonuses of the user use the ListUserBonusesPaginator.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/bonuses
+func (c *Client) ListUserBonuses(ctx context.Context, params *ListUserBonusesInput) (*ListUserBonusesOutput, error) {
+	if params == nil || params.UserId == "" {
+		return nil, ErrMissingUserId
+	}
+
+	u, err := newListUserBonusesURL(c.endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result []Bonus `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("list user bonuses", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUserBonusesOutput{Bonuses: r.Result}, nil
+}
+
+// newListUserBonusesURL returns the URL to get a list of bonuses of a user (ListUserBonuses) based on the provided
+// endpoint and params. If the URL can not be created a non-nil error is returned and the URL is nil.
+func newListUserBonusesURL(endpoint Endpoint, params *ListUserBonusesInput) (*url.URL, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/users/%s/bonuses", endpoint, params.UserId))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+
+	if params.Role != "" {
+		q.Add("role", string(params.Role))
+	}
+
+	if params.Limit > 0 {
+		q.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	if params.Skip > 0 {
+		q.Add("skip", strconv.Itoa(params.Skip))
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u, nil
+}
+
+var (
+	ErrMissingBonusId = errors.New("missing bonus id")
+)
+
+// GetBonusInput represents the input of the "Retrieve a Bonus" operation.
+type GetBonusInput struct {
+	// Id of the bonus to retrieve.
+	Id string
+}
+
+// GetBonusOutput represents the output of the "Retrieve a Bonus" operation.
+type GetBonusOutput struct {
+	// Bonus is the retrieved bonus.
+	Bonus Bonus
+}
+
+// GetBonus returns a single bonus.
+//
+// If the bonus does not exist, the returned error matches ErrNotFound. If the token is not allowed to see the bonus,
+// the returned error matches ErrForbidden.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/bonuses/retrieve-a-bonus
+func (c *Client) GetBonus(ctx context.Context, params *GetBonusInput) (*GetBonusOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingBonusId
+	}
+
+	u := fmt.Sprintf("%s/bonuses/%s", c.endpoint, url.PathEscape(params.Id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result Bonus `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("get bonus", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetBonusOutput{Bonus: r.Result}, nil
+}
+
+// UpdateBonusInput represents the input of the "Update a Bonus" operation.
+type UpdateBonusInput struct {
+	// Id of the bonus to update.
+	Id string
+	// Reason is the new reason of the bonus.
+	Reason string
+}
+
+// UpdateBonusOutput represents the output of the "Update a Bonus" operation.
+type UpdateBonusOutput struct {
+	// Bonus is the updated bonus.
+	Bonus Bonus
+}
+
+// UpdateBonus updates the reason of a bonus. This operation requires a token with write access.
+//
+// If the bonus does not exist, the returned error matches ErrNotFound. If the token is not allowed to update the
+// bonus, the returned error matches ErrForbidden.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/bonuses/update-a-bonus
+func (c *Client) UpdateBonus(ctx context.Context, params *UpdateBonusInput) (*UpdateBonusOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingBonusId
+	}
+
+	b, err := json.Marshal(struct {
+		Reason string `json:"reason"`
+	}{
+		Reason: params.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/bonuses/%s", c.endpoint, url.PathEscape(params.Id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result Bonus `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("update bonus", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateBonusOutput{Bonus: r.Result}, nil
+}
+
+// DeleteBonusInput represents the input of the "Delete a Bonus" operation.
+type DeleteBonusInput struct {
+	// Id of the bonus to delete.
+	Id string
+}
+
+// DeleteBonusOutput represents the output of the "Delete a Bonus" operation.
+type DeleteBonusOutput struct {
+	// Id of the deleted bonus.
+	Id string
+}
+
+// DeleteBonus deletes a bonus. This operation requires a token with write access.
+//
+// If the bonus does not exist, the returned error matches ErrNotFound. If the token is not allowed to delete the
+// bonus, the returned error matches ErrForbidden.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/bonuses/delete-a-bonus
+func (c *Client) DeleteBonus(ctx context.Context, params *DeleteBonusInput) (*DeleteBonusOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingBonusId
+	}
+
+	u := fmt.Sprintf("%s/bonuses/%s", c.endpoint, url.PathEscape(params.Id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var r baseAPIResponse
+	err = decodeResponse("delete bonus", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteBonusOutput{Id: params.Id}, nil
 }
@@ -1,7 +1,17 @@
 package bonusly
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_newReason(t *testing.T) {
@@ -42,3 +52,534 @@ func Test_newReason(t *testing.T) {
 		})
 	}
 }
+
+func Test_newCreateBonusBody(t *testing.T) {
+	type args struct {
+		params    *CreateBonusInput
+		formatter ReasonFormatter
+	}
+	tests := []struct {
+		name string
+		args args
+		want createBonusBody
+	}{
+		{
+			"reason-default-formatter",
+			args{params: &CreateBonusInput{
+				GiverEmail: "test@example.com",
+				Receivers:  []string{"bilbo.baggins"},
+				Reason:     "Test Reason",
+				Amount:     10,
+			}},
+			createBonusBody{GiverEmail: "test@example.com", Reason: "+10 @bilbo.baggins Test Reason"},
+		},
+		{
+			"reason-hashtags",
+			args{params: &CreateBonusInput{
+				GiverEmail: "test@example.com",
+				Receivers:  []string{"bilbo.baggins"},
+				Reason:     "for #Teamwork",
+				Amount:     10,
+				Hashtags:   []string{"teamwork", "#innovation"},
+			}},
+			createBonusBody{GiverEmail: "test@example.com", Reason: "+10 @bilbo.baggins for #Teamwork #innovation"},
+		},
+		{
+			"reason-custom-formatter",
+			args{
+				params: &CreateBonusInput{
+					GiverEmail:    "test@example.com",
+					Receivers:     []string{"bilbo.baggins"},
+					Reason:        "Test Reason",
+					Amount:        10,
+					ParentBonusID: "123",
+				},
+				formatter: func(params *CreateBonusInput) string {
+					return fmt.Sprintf("%s +%d @%s", params.Reason, params.Amount, params.Receivers[0])
+				},
+			},
+			createBonusBody{GiverEmail: "test@example.com", Reason: "Test Reason +10 @bilbo.baggins", ParentBonusID: "123"},
+		},
+		{
+			"structured",
+			args{params: &CreateBonusInput{
+				GiverEmail: "test@example.com",
+				Receivers:  []string{"bilbo@example.com", " frodo@example.com "},
+				Reason:     "for +1 and @everyone",
+				Amount:     10,
+				Hashtags:   []string{"teamwork", "# innovation"},
+				Format:     BonusFormatStructured,
+			}},
+			createBonusBody{
+				GiverEmail:    "test@example.com",
+				Reason:        "for +1 and @everyone #innovation",
+				ReceiverEmail: "bilbo@example.com,frodo@example.com",
+				Amount:        10,
+				Hashtag:       "#teamwork",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newCreateBonusBody(tt.args.params, tt.args.formatter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newCreateBonusBody() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBonus_Unmarshal(t *testing.T) {
+	data := []byte(`{
+		"id": "24abcdef1234567890abcdef",
+		"created_at": "2022-03-01T15:32:03Z",
+		"reason": "+10 @bilbo.baggins for #teamwork",
+		"reason_html": "+10 <b>@bilbo.baggins</b> for #teamwork",
+		"amount": 10,
+		"amount_with_currency": "10 points",
+		"family_amount": 15,
+		"hashtag": "#teamwork",
+		"value": "teamwork",
+		"via": "web",
+		"giver": {"id": "1", "email": "frodo@example.com"},
+		"receivers": [{"id": "2", "email": "bilbo@example.com"}],
+		"child_count": 1,
+		"child_bonuses": [{"id": "3", "amount": 5, "parent_bonus_id": "24abcdef1234567890abcdef"}]
+	}`)
+
+	var got Bonus
+	err := json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+
+	if !got.CreatedAt.Equal(time.Date(2022, 3, 1, 15, 32, 3, 0, time.UTC)) {
+		t.Errorf("UnmarshalJSON() CreatedAt = %v", got.CreatedAt)
+	}
+	if got.Giver.Email != "frodo@example.com" {
+		t.Errorf("UnmarshalJSON() Giver = %v, want %v", got.Giver.Email, "frodo@example.com")
+	}
+	if len(got.Receivers) != 1 || got.Receivers[0].Email != "bilbo@example.com" {
+		t.Errorf("UnmarshalJSON() Receivers = %v", got.Receivers)
+	}
+	if len(got.ChildBonuses) != 1 || got.ChildBonuses[0].ParentBonusId != got.Id {
+		t.Errorf("UnmarshalJSON() ChildBonuses = %v", got.ChildBonuses)
+	}
+	if got.Hashtag != "#teamwork" || got.Amount != 10 || got.FamilyAmount != 15 {
+		t.Errorf("UnmarshalJSON() got = %+v", got)
+	}
+}
+
+type mockListBonusesClient struct {
+	pages []*ListBonusesOutput
+	c     int
+}
+
+func (m *mockListBonusesClient) ListBonuses(context.Context, *ListBonusesInput) (*ListBonusesOutput, error) {
+	p := m.pages[m.c]
+	m.c++
+
+	return p, nil
+}
+
+func TestListBonusesPaginator(t *testing.T) {
+	client := &mockListBonusesClient{
+		pages: []*ListBonusesOutput{
+			{Bonuses: newBonuses(t, 20)},
+			{Bonuses: newBonuses(t, 20)},
+			{Bonuses: newBonuses(t, 3)},
+		},
+	}
+
+	var bonuses []Bonus
+
+	paginator := NewListBonusesPaginator(client, nil)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		bonuses = append(bonuses, output.Bonuses...)
+	}
+
+	if len(bonuses) != 43 {
+		t.Errorf("ListBonusesPaginator(), got = %d, want = %d", len(bonuses), 43)
+	}
+}
+
+func TestListBonusesPaginator_DefaultLimit(t *testing.T) {
+	client := &mockListBonusesClient{
+		pages: []*ListBonusesOutput{
+			{Bonuses: newBonuses(t, 20)},
+			{Bonuses: newBonuses(t, 3)},
+		},
+	}
+
+	var bonuses []Bonus
+
+	paginator := NewListBonusesPaginator(client, &ListBonusesInput{GiverEmail: "frodo@example.com"})
+	for paginator.HasMorePages() {
+		if client.c >= len(client.pages) {
+			t.Fatalf("ListBonusesPaginator() requested more than %d pages", len(client.pages))
+		}
+
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		bonuses = append(bonuses, output.Bonuses...)
+	}
+
+	if len(bonuses) != 23 {
+		t.Errorf("ListBonusesPaginator(), got = %d, want = %d", len(bonuses), 23)
+	}
+}
+
+func newBonuses(t *testing.T, num int) []Bonus {
+	t.Helper()
+
+	var bonuses []Bonus
+	for i := 0; i < num; i++ {
+		bonuses = append(bonuses, Bonus{Id: fmt.Sprintf("%d", i)})
+	}
+
+	return bonuses
+}
+
+func Test_newListBonusesURL(t *testing.T) {
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	type args struct {
+		params *ListBonusesInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *url.URL
+		wantErr bool
+	}{
+		{
+			"no-settings",
+			args{params: &ListBonusesInput{}},
+			mustURL(t, fmt.Sprintf("%s/bonuses", EndpointProduction)),
+			false,
+		},
+		{
+			"limit-skip",
+			args{params: &ListBonusesInput{Limit: 25, Skip: 50}},
+			mustURL(t, fmt.Sprintf("%s/bonuses?limit=25&skip=50", EndpointProduction)),
+			false,
+		},
+		{
+			"time-range",
+			args{params: &ListBonusesInput{StartTime: start, EndTime: end}},
+			mustURL(t, fmt.Sprintf("%s/bonuses?end_time=%s&start_time=%s", EndpointProduction,
+				url.QueryEscape("2022-03-31T23:59:59Z"), url.QueryEscape("2022-03-01T00:00:00Z"))),
+			false,
+		},
+		{
+			"emails",
+			args{params: &ListBonusesInput{GiverEmail: "a@example.com", ReceiverEmail: "b@example.com", UserEmail: "c@example.com"}},
+			mustURL(t, fmt.Sprintf("%s/bonuses?giver_email=%s&receiver_email=%s&user_email=%s", EndpointProduction,
+				url.QueryEscape("a@example.com"), url.QueryEscape("b@example.com"), url.QueryEscape("c@example.com"))),
+			false,
+		},
+		{
+			"hashtag",
+			args{params: &ListBonusesInput{Hashtag: "#teamwork"}},
+			mustURL(t, fmt.Sprintf("%s/bonuses?hashtag=%s", EndpointProduction, url.QueryEscape("#teamwork"))),
+			false,
+		},
+		{
+			"include_children",
+			args{params: &ListBonusesInput{IncludeChildren: true}},
+			mustURL(t, fmt.Sprintf("%s/bonuses?include_children=true", EndpointProduction)),
+			false,
+		},
+		{
+			"custom_property",
+			args{params: &ListBonusesInput{CustomPropertyName: "department=marketing"}},
+			mustURL(t, fmt.Sprintf("%s/bonuses?custom_property_name=%s", EndpointProduction, url.QueryEscape("department=marketing"))),
+			false,
+		},
+		{
+			"show_private_bonuses",
+			args{params: &ListBonusesInput{ShowPrivateBonuses: true}},
+			mustURL(t, fmt.Sprintf("%s/bonuses?show_private_bonuses=true", EndpointProduction)),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newListBonusesURL(EndpointProduction, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newListBonusesURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("newListBonusesURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetBonus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantId     string
+		wantErr    error
+	}{
+		{
+			"ok",
+			http.StatusOK,
+			`{"success": true, "result": {"id": "123", "amount": 10}}`,
+			"123",
+			nil,
+		},
+		{
+			"not-found",
+			http.StatusNotFound,
+			`{"success": false, "message": "Bonus not found"}`,
+			"",
+			ErrNotFound,
+		},
+		{
+			"forbidden",
+			http.StatusForbidden,
+			`{"success": false, "message": "Forbidden"}`,
+			"",
+			ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/bonuses/123" {
+					t.Errorf("GetBonus() path = %v, want %v", r.URL.Path, "/bonuses/123")
+				}
+
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+			got, err := client.GetBonus(context.TODO(), &GetBonusInput{Id: "123"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetBonus() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetBonus() error = %v", err)
+			}
+			if got.Bonus.Id != tt.wantId {
+				t.Errorf("GetBonus() got = %v, want %v", got.Bonus.Id, tt.wantId)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateBonus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    error
+	}{
+		{"ok", http.StatusOK, `{"success": true, "result": {"id": "a/1", "reason": "Thanks!"}}`, nil},
+		{"not-found", http.StatusNotFound, `{"success": false, "message": "Bonus not found"}`, ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if r.Method != http.MethodPut || r.URL.EscapedPath() != "/bonuses/a%2F1" || string(body) != `{"reason":"Thanks!"}` {
+					t.Errorf("UpdateBonus() request = %v %v %s", r.Method, r.URL.EscapedPath(), body)
+				}
+
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+			got, err := client.UpdateBonus(context.TODO(), &UpdateBonusInput{Id: "a/1", Reason: "Thanks!"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("UpdateBonus() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateBonus() error = %v", err)
+			}
+			if got.Bonus.Id != "a/1" || got.Bonus.Reason != "Thanks!" {
+				t.Errorf("UpdateBonus() got = %+v", got.Bonus)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteBonus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    error
+	}{
+		{"ok", http.StatusOK, `{"success": true}`, nil},
+		{"not-found", http.StatusNotFound, `{"success": false, "message": "Bonus not found"}`, ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if r.Method != http.MethodDelete || r.URL.EscapedPath() != "/bonuses/a%2F1" || len(body) != 0 {
+					t.Errorf("DeleteBonus() request = %v %v %s", r.Method, r.URL.EscapedPath(), body)
+				}
+
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+			got, err := client.DeleteBonus(context.TODO(), &DeleteBonusInput{Id: "a/1"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("DeleteBonus() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteBonus() error = %v", err)
+			}
+			if got.Id != "a/1" {
+				t.Errorf("DeleteBonus() got = %v, want %v", got.Id, "a/1")
+			}
+		})
+	}
+}
+
+func TestClient_MissingBonusId(t *testing.T) {
+	client := New(Configuration{Token: "test"})
+
+	if _, err := client.GetBonus(context.TODO(), nil); !errors.Is(err, ErrMissingBonusId) {
+		t.Errorf("GetBonus() error = %v, want %v", err, ErrMissingBonusId)
+	}
+	if _, err := client.UpdateBonus(context.TODO(), &UpdateBonusInput{}); !errors.Is(err, ErrMissingBonusId) {
+		t.Errorf("UpdateBonus() error = %v, want %v", err, ErrMissingBonusId)
+	}
+	if _, err := client.DeleteBonus(context.TODO(), &DeleteBonusInput{}); !errors.Is(err, ErrMissingBonusId) {
+		t.Errorf("DeleteBonus() error = %v, want %v", err, ErrMissingBonusId)
+	}
+}
+
+func TestClient_CreateBonus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/bonuses" {
+			t.Errorf("CreateBonus() request = %v %v, want %v %v", r.Method, r.URL.Path, http.MethodPost, "/bonuses")
+		}
+
+		_, _ = w.Write([]byte(`{
+			"success": true,
+			"result": {
+				"id": "123",
+				"amount": 10,
+				"hashtag": "#teamwork",
+				"parent_bonus_id": "100",
+				"receivers": [{"id": "2", "email": "bilbo@example.com"}]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	got, err := client.CreateBonus(context.TODO(), &CreateBonusInput{
+		GiverEmail:    "frodo@example.com",
+		Receivers:     []string{"bilbo@example.com"},
+		Reason:        "for #teamwork",
+		Amount:        10,
+		ParentBonusID: "100",
+	})
+	if err != nil {
+		t.Fatalf("CreateBonus() error = %v", err)
+	}
+
+	if got.Bonus.Id != "123" || got.Bonus.Amount != 10 || got.Bonus.Hashtag != "#teamwork" || got.Bonus.ParentBonusId != "100" {
+		t.Errorf("CreateBonus() got = %+v", got.Bonus)
+	}
+	if len(got.Bonus.Receivers) != 1 || got.Bonus.Receivers[0].Email != "bilbo@example.com" {
+		t.Errorf("CreateBonus() receivers = %v", got.Bonus.Receivers)
+	}
+
+	if _, err := client.CreateBonus(context.TODO(), nil); !errors.Is(err, ErrMissingParams) {
+		t.Errorf("CreateBonus() error = %v, want %v", err, ErrMissingParams)
+	}
+}
+
+func Test_newListUserBonusesURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *ListUserBonusesInput
+		want   string
+	}{
+		{"no-settings", &ListUserBonusesInput{UserId: "1"}, fmt.Sprintf("%s/users/1/bonuses", EndpointProduction)},
+		{"given", &ListUserBonusesInput{UserId: "1", Role: UserBonusRoleGiven}, fmt.Sprintf("%s/users/1/bonuses?role=given", EndpointProduction)},
+		{"received", &ListUserBonusesInput{UserId: "1", Role: UserBonusRoleReceived}, fmt.Sprintf("%s/users/1/bonuses?role=received", EndpointProduction)},
+		{"limit-skip", &ListUserBonusesInput{UserId: "1", Limit: 10, Skip: 20}, fmt.Sprintf("%s/users/1/bonuses?limit=10&skip=20", EndpointProduction)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newListUserBonusesURL(EndpointProduction, tt.params)
+			if err != nil {
+				t.Fatalf("newListUserBonusesURL() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("newListUserBonusesURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListUserBonuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "/users/1/bonuses?limit=2&role=given"
+		if r.URL.String() != want {
+			t.Errorf("ListUserBonuses() URL = %v, want %v", r.URL, want)
+		}
+
+		_, _ = w.Write([]byte(`{"success": true, "result": [{"id": "b1"}, {"id": "b2"}]}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	paginator := NewListUserBonusesPaginator(client, &ListUserBonusesInput{UserId: "1", Role: UserBonusRoleGiven, Limit: 2})
+	output, err := paginator.NextPage(context.TODO())
+	if err != nil {
+		t.Fatalf("ListUserBonuses() error = %v", err)
+	}
+
+	if len(output.Bonuses) != 2 || output.Bonuses[0].Id != "b1" {
+		t.Errorf("ListUserBonuses() got = %+v", output.Bonuses)
+	}
+	if !paginator.HasMorePages() {
+		t.Errorf("ListUserBonusesPaginator.HasMorePages() = false, want true")
+	}
+
+	_, err = client.ListUserBonuses(context.TODO(), nil)
+	if !errors.Is(err, ErrMissingUserId) {
+		t.Errorf("ListUserBonuses() error = %v, want %v", err, ErrMissingUserId)
+	}
+}
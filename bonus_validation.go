@@ -0,0 +1,210 @@
+package bonusly
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// BonusViolationCode identifies the kind of a BonusViolation.
+type BonusViolationCode string
+
+const (
+	// BonusViolationNoReceivers means the bonus has no receivers.
+	BonusViolationNoReceivers BonusViolationCode = "no_receivers"
+	// BonusViolationGiverNotFound means no user with the email of the giver exists.
+	BonusViolationGiverNotFound BonusViolationCode = "giver_not_found"
+	// BonusViolationGiverCannotGive means the giver is not allowed to give bonuses, for example because the giver is
+	// an observer or a receiver-only user.
+	BonusViolationGiverCannotGive BonusViolationCode = "giver_cannot_give"
+	// BonusViolationReceiverNotFound means no user with the email of a receiver exists.
+	BonusViolationReceiverNotFound BonusViolationCode = "receiver_not_found"
+	// BonusViolationReceiverCannotReceive means a receiver is not allowed to receive bonuses, for example because the
+	// receiver is an observer or a benefactor-only user.
+	BonusViolationReceiverCannotReceive BonusViolationCode = "receiver_cannot_receive"
+	// BonusViolationSelfBonus means the giver is also one of the receivers.
+	BonusViolationSelfBonus BonusViolationCode = "self_bonus"
+	// BonusViolationInvalidAmount means the amount is not one of the amounts the giver is allowed to give.
+	BonusViolationInvalidAmount BonusViolationCode = "invalid_amount"
+	// BonusViolationInsufficientBalance means the giving balance of the giver is too small for the amount multiplied
+	// by the number of receivers.
+	BonusViolationInsufficientBalance BonusViolationCode = "insufficient_balance"
+)
+
+// BonusViolation describes why a bonus can not be created.
+type BonusViolation struct {
+	// Code identifies the kind of violation.
+	Code BonusViolationCode
+	// Email of the user the violation refers to. Empty if the violation does not refer to a single user.
+	Email string
+	// Message is a human-readable description of the violation.
+	Message string
+}
+
+// String implements the fmt.Stringer interface.
+func (v BonusViolation) String() string {
+	return v.Message
+}
+
+// ValidateBonus checks if the bonus described by params can be created, without creating the bonus.
+//
+// The following checks are performed:
+//   - The giver exists and is allowed to give bonuses.
+//   - Every receiver exists and is allowed to receive bonuses.
+//   - The amount is one of the amounts the giver is allowed to give.
+//   - The amount multiplied by the number of receivers fits into the giving balance of the giver.
+//
+// The giver and receivers are looked up by email, so Receivers must contain emails. If the GiverEmail is empty, the
+// owner of the token is the giver. If the bonus is valid, the returned slice is empty. An error is only returned if
+// the users could not be retrieved.
+func (c *Client) ValidateBonus(ctx context.Context, params *CreateBonusInput) ([]BonusViolation, error) {
+	if params == nil {
+		return nil, ErrMissingParams
+	}
+
+	giver, err := c.findGiver(ctx, params.GiverEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	receivers := make(map[string]*User, len(params.Receivers))
+	for _, email := range params.Receivers {
+		email = strings.TrimSpace(email)
+
+		u, err := c.findUserByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+
+		receivers[email] = u
+	}
+
+	return checkBonus(params, giver, receivers), nil
+}
+
+// findGiver returns the user with the given email, including the giving balance. If the email is empty, the owner of
+// the token is returned. If no user exists, nil is returned.
+func (c *Client) findGiver(ctx context.Context, email string) (*ExtendedUser, error) {
+	if email == "" {
+		out, err := c.Me(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &out.User, nil
+	}
+
+	u, err := c.findUserByEmail(ctx, email)
+	if err != nil || u == nil {
+		return nil, err
+	}
+
+	out, err := c.GetUser(ctx, &GetUserInput{Id: u.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out.User, nil
+}
+
+// findUserByEmail returns the user with the given email. If no user exists, nil is returned.
+func (c *Client) findUserByEmail(ctx context.Context, email string) (*User, error) {
+	out, err := c.ListUsers(ctx, &ListUsersInput{Email: email, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range out.Users {
+		if strings.EqualFold(out.Users[i].Email, email) {
+			return &out.Users[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkBonus returns all violations of the bonus described by params. The giver is nil if the giver does not exist.
+// The receivers map contains every receiver of params, with a nil value if the receiver does not exist.
+//nolint: cyclop
+func checkBonus(params *CreateBonusInput, giver *ExtendedUser, receivers map[string]*User) []BonusViolation {
+	var violations []BonusViolation
+
+	if len(params.Receivers) == 0 {
+		violations = append(violations, BonusViolation{
+			Code:    BonusViolationNoReceivers,
+			Message: "bonus has no receivers",
+		})
+	}
+
+	for _, email := range params.Receivers {
+		email = strings.TrimSpace(email)
+
+		r := receivers[email]
+		switch {
+		case r == nil:
+			violations = append(violations, BonusViolation{
+				Code:    BonusViolationReceiverNotFound,
+				Email:   email,
+				Message: fmt.Sprintf("receiver %s does not exist", email),
+			})
+		case !r.CanReceive || r.UserMode == UserModeObserver || r.UserMode == UserModeBenefactor:
+			violations = append(violations, BonusViolation{
+				Code:    BonusViolationReceiverCannotReceive,
+				Email:   email,
+				Message: fmt.Sprintf("receiver %s can not receive bonuses", email),
+			})
+		case giver != nil && r.Id == giver.Id:
+			violations = append(violations, BonusViolation{
+				Code:    BonusViolationSelfBonus,
+				Email:   email,
+				Message: fmt.Sprintf("%s can not give a bonus to themselves", email),
+			})
+		}
+	}
+
+	if giver == nil {
+		return append(violations, BonusViolation{
+			Code:    BonusViolationGiverNotFound,
+			Email:   params.GiverEmail,
+			Message: fmt.Sprintf("giver %s does not exist", params.GiverEmail),
+		})
+	}
+
+	if !giver.CanGive || giver.UserMode == UserModeObserver || giver.UserMode == UserModeReceiver {
+		violations = append(violations, BonusViolation{
+			Code:    BonusViolationGiverCannotGive,
+			Email:   giver.Email,
+			Message: fmt.Sprintf("giver %s can not give bonuses", giver.Email),
+		})
+	}
+
+	if len(giver.GiveAmounts) > 0 && !containsAmount(giver.GiveAmounts, int(params.Amount)) {
+		violations = append(violations, BonusViolation{
+			Code:    BonusViolationInvalidAmount,
+			Email:   giver.Email,
+			Message: fmt.Sprintf("amount %d is not allowed, allowed amounts are %v", params.Amount, giver.GiveAmounts),
+		})
+	}
+
+	total := int(params.Amount) * len(params.Receivers)
+	if total > giver.GiveBalance {
+		violations = append(violations, BonusViolation{
+			Code:  BonusViolationInsufficientBalance,
+			Email: giver.Email,
+			Message: fmt.Sprintf("bonus requires %d points, but giver %s has only %d points left to give",
+				total, giver.Email, giver.GiveBalance),
+		})
+	}
+
+	return violations
+}
+
+func containsAmount(amounts []int, amount int) bool {
+	for _, a := range amounts {
+		if a == amount {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func Test_checkBonus(t *testing.T) {
+	giver := &ExtendedUser{
+		BaseUser:    BaseUser{Id: "1", Email: "frodo@example.com", CanGive: true, UserMode: UserModeNormal, GiveAmounts: []int{5, 10}},
+		GiveBalance: 20,
+	}
+	bilbo := &User{BaseUser{Id: "2", Email: "bilbo@example.com", CanReceive: true, UserMode: UserModeNormal}}
+	sam := &User{BaseUser{Id: "3", Email: "sam@example.com", CanReceive: true, UserMode: UserModeNormal}}
+	observer := &User{BaseUser{Id: "4", Email: "gandalf@example.com", CanReceive: true, UserMode: UserModeObserver}}
+	self := &User{giver.BaseUser}
+	self.CanReceive = true
+
+	receivers := map[string]*User{
+		"bilbo@example.com":   bilbo,
+		"sam@example.com":     sam,
+		"gandalf@example.com": observer,
+		"frodo@example.com":   self,
+		"nobody@example.com":  nil,
+	}
+
+	tests := []struct {
+		name   string
+		params *CreateBonusInput
+		giver  *ExtendedUser
+		want   []BonusViolationCode
+	}{
+		{
+			"valid",
+			&CreateBonusInput{GiverEmail: "frodo@example.com", Receivers: []string{"bilbo@example.com", "sam@example.com"}, Amount: 10},
+			giver,
+			nil,
+		},
+		{
+			"no-receivers",
+			&CreateBonusInput{GiverEmail: "frodo@example.com", Amount: 10},
+			giver,
+			[]BonusViolationCode{BonusViolationNoReceivers},
+		},
+		{
+			"giver-not-found",
+			&CreateBonusInput{GiverEmail: "nobody@example.com", Receivers: []string{"bilbo@example.com"}, Amount: 10},
+			nil,
+			[]BonusViolationCode{BonusViolationGiverNotFound},
+		},
+		{
+			"giver-cannot-give",
+			&CreateBonusInput{GiverEmail: "frodo@example.com", Receivers: []string{"bilbo@example.com"}, Amount: 10},
+			&ExtendedUser{BaseUser: BaseUser{Id: "1", UserMode: UserModeReceiver}, GiveBalance: 100},
+			[]BonusViolationCode{BonusViolationGiverCannotGive},
+		},
+		{
+			"receivers",
+			&CreateBonusInput{GiverEmail: "frodo@example.com", Receivers: []string{"nobody@example.com", "gandalf@example.com", "frodo@example.com"}, Amount: 5},
+			giver,
+			[]BonusViolationCode{BonusViolationReceiverNotFound, BonusViolationReceiverCannotReceive, BonusViolationSelfBonus},
+		},
+		{
+			"invalid-amount",
+			&CreateBonusInput{GiverEmail: "frodo@example.com", Receivers: []string{"bilbo@example.com"}, Amount: 7},
+			giver,
+			[]BonusViolationCode{BonusViolationInvalidAmount},
+		},
+		{
+			"insufficient-balance",
+			&CreateBonusInput{GiverEmail: "frodo@example.com", Receivers: []string{"bilbo@example.com", "sam@example.com", " bilbo@example.com"}, Amount: 10},
+			giver,
+			[]BonusViolationCode{BonusViolationInsufficientBalance},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []BonusViolationCode
+			for _, v := range checkBonus(tt.params, tt.giver, receivers) {
+				got = append(got, v.Code)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkBonus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ValidateBonus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users":
+			switch r.URL.Query().Get("email") {
+			case "frodo@example.com":
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"id": "1", "email": "frodo@example.com"}]}`))
+			case "bilbo@example.com":
+				_, _ = w.Write([]byte(`{"success": true, "result": [{"id": "2", "email": "bilbo@example.com", "can_receive": true, "user_mode": "normal"}]}`))
+			default:
+				_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+			}
+		case "/users/1", "/users/me":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": "1", "email": "frodo@example.com", "can_give": true, "user_mode": "normal", "give_amounts": [5, 10], "giving_balance": 1000}}`))
+		default:
+			t.Errorf("unexpected request to %v", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	tests := []struct {
+		name   string
+		params *CreateBonusInput
+		want   []BonusViolationCode
+	}{
+		{
+			"giver-email",
+			&CreateBonusInput{GiverEmail: "frodo@example.com", Receivers: []string{"bilbo@example.com", "nobody@example.com"}, Amount: 10},
+			[]BonusViolationCode{BonusViolationReceiverNotFound},
+		},
+		{
+			"token-owner",
+			&CreateBonusInput{Receivers: []string{"bilbo@example.com"}, Amount: 10},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ValidateBonus(context.TODO(), tt.params)
+			if err != nil {
+				t.Fatalf("ValidateBonus() error = %v", err)
+			}
+
+			var codes []BonusViolationCode
+			for _, v := range got {
+				codes = append(codes, v.Code)
+			}
+
+			if !reflect.DeepEqual(codes, tt.want) {
+				t.Errorf("ValidateBonus() = %v, want %v", codes, tt.want)
+			}
+		})
+	}
+
+	if _, err := client.ValidateBonus(context.TODO(), nil); !errors.Is(err, ErrMissingParams) {
+		t.Errorf("ValidateBonus() error = %v, want %v", err, ErrMissingParams)
+	}
+}
@@ -1,18 +1,22 @@
 package bonusly
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sync"
+	"time"
 )
 
 // New creates a new Client that can be used to interact with the Bonus.ly REST API.
 func New(cfg Configuration, options ...ClientOption) *Client {
 	c := &Client{
-		httpClient:      http.DefaultClient,
-		token:           cfg.Token,
-		endpoint:        EndpointProduction,
-		applicationName: DefaultApplicationName,
+		httpClient:        http.DefaultClient,
+		tokenProvider:     StaticToken(cfg.Token),
+		endpoint:          EndpointProduction,
+		applicationName:   DefaultApplicationName,
+		maxRateLimitPause: DefaultMaxRateLimitPause,
 	}
 
 	for _, fn := range options {
@@ -65,13 +69,16 @@ type Client struct {
 	// To use your own client use bonusly.WithHttpClient option when creating a new bonusly.Client.
 	httpClient *http.Client
 
-	// token is the Bonus.ly authentication token that is used for requests to the Bonus.ly REST API.
+	// tokenProvider provides the Bonus.ly authentication token that is used for requests to the Bonus.ly REST API.
 	//
 	// There are several kinds of tokens. Read-only tokens, Read and write tokens and admin tokens. All functions
 	// require at least a read-only token, but some also require a write or admin token.
 	//
-	// The token can be set through the bonusly.Configuration.
-	token string
+	// A static token can be set through the bonusly.Configuration. To rotate tokens without creating a new client use
+	// the bonusly.WithTokenProvider option when creating a new bonusly.Client using the bonusly.New() function.
+	//
+	// Default: StaticToken(Configuration.Token)
+	tokenProvider TokenProvider
 
 	// endpoint is the HTTP endpoint the bonusly.Client sends requests to.
 	//
@@ -92,14 +99,94 @@ type Client struct {
 	//
 	// Default: DefaultApplicationName
 	applicationName string
+
+	// retryPolicy defines if and how failed requests are retried. If the retryPolicy is nil, requests are not retried.
+	//
+	// The retryPolicy can be set using the bonusly.WithRetryPolicy option when creating a new bonusly.Client using the
+	// bonusly.New() function.
+	//
+	// Default: nil
+	retryPolicy *RetryPolicy
+
+	// rateLimiter limits the number of requests sent to the Bonus.ly REST API. The limit is shared by all operations
+	// of the client. If the rateLimiter is nil, requests are not limited.
+	//
+	// The rateLimiter can be set using the bonusly.WithRateLimit option when creating a new bonusly.Client using the
+	// bonusly.New() function.
+	//
+	// Default: nil
+	rateLimiter *rateLimiter
+
+	// maxRateLimitPause is the maximum time requests wait if the Bonus.ly REST API asks to pause all requests.
+	//
+	// The maxRateLimitPause can be set using the bonusly.WithMaxRateLimitPause option when creating a new
+	// bonusly.Client using the bonusly.New() function.
+	//
+	// Default: DefaultMaxRateLimitPause
+	maxRateLimitPause time.Duration
+
+	// reasonFormatter creates the reason string of bonuses created with the BonusFormatReason format.
+	//
+	// The reasonFormatter can be set using the bonusly.WithReasonFormatter option when creating a new bonusly.Client
+	// using the bonusly.New() function.
+	//
+	// Default: "+<amount> @<receiver> ... <reason>"
+	reasonFormatter ReasonFormatter
+
+	// validateHashtags enables the validation of the hashtags of a bonus against the hashtags configured for the
+	// company before the bonus is created.
+	//
+	// The validation can be enabled using the bonusly.WithHashtagValidation option when creating a new
+	// bonusly.Client using the bonusly.New() function.
+	//
+	// Default: false
+	validateHashtags bool
+
+	// redemptions remembers the redemptions created by CreateRedemption with an idempotency key, so the same
+	// redemption is not created twice by the client. Created redemptions are remembered for a limited time only.
+	//
+	// The redemptions are created on first use by redemptionCache, so a Client that was not created using the
+	// bonusly.New() function can create redemptions, too.
+	//
+	// Default: empty
+	redemptions     *redemptionCache
+	redemptionsOnce sync.Once
 }
 
+// Do sends the request to the Bonus.ly REST API and returns the response.
+//
+// Do adds the authentication and application headers to the request. The token is requested from the TokenProvider
+// of the client for every attempt, so retried requests use a rotated token. If a RetryPolicy is configured, failed
+// requests are retried according to the policy. If a rate limit is configured, every attempt waits for the rate limit.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
 	req.Header.Set("HTTP_APPLICATION_NAME", c.applicationName)
 	req.Header.Set("Content-Type", "application/json")
 
-	return c.httpClient.Do(req)
+	return c.retryPolicy.do(req, c.send)
+}
+
+// send sends a single attempt of the request with the current token, respecting the rate limit of the client.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	token, err := c.tokenProvider.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("get token: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	err = c.rateLimiter.wait(req.Context(), c.maxRateLimitPause)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.rateLimiter.update(resp)
+
+	return resp, nil
 }
 
 // Endpoint is the Bonus.ly REST API endpoint to which requests are sent to.
@@ -121,6 +208,8 @@ const DefaultApplicationName string = "Bonus.ly Go SDK"
 // Configuration represents the Client configuration for the Bonus.ly API.
 type Configuration struct {
 	// Token is the access token used to authenticate requests with the Bonus.ly REST API.
+	//
+	// The Token is ignored if a TokenProvider is set using the bonusly.WithTokenProvider option.
 	Token string
 }
 
@@ -129,6 +218,44 @@ type baseAPIResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+func (r baseAPIResponse) base() baseAPIResponse {
+	return r
+}
+
+// apiResponse is implemented by every response type that embeds baseAPIResponse.
+type apiResponse interface {
+	base() baseAPIResponse
+}
+
+// decodeResponse reads and closes the body of the given *http.Response and decodes it into v.
+//
+// If the response has a non-2xx status code, the body is not valid JSON for an error response or the Bonus.ly REST API
+// reports that the request was not successful, an *APIError for the operation op is returned.
+func decodeResponse(op string, resp *http.Response, v apiResponse) error {
+	body, err := readAndCloseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	ok := resp.StatusCode >= 200 && resp.StatusCode <= 299
+
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		if !ok {
+			return newAPIError(op, resp, body, "")
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	r := v.base()
+	if !ok || !r.Success {
+		return newAPIError(op, resp, body, r.Message)
+	}
+
+	return nil
+}
+
 // readAndCloseBody returns the body of the given *http.Response.
 //
 // After reading the body it will be closed. If reading the body and closing the body cause an error the returned error
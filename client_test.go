@@ -70,3 +70,94 @@ func Test_readAndCloseBody(t *testing.T) {
 		})
 	}
 }
+
+func Test_decodeResponse(t *testing.T) {
+	type response struct {
+		baseAPIResponse
+
+		Result string `json:"result"`
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       string
+		wantErr    error
+		wantAPIErr bool
+	}{
+		{
+			"ok",
+			http.StatusOK,
+			`{"success": true, "result": "test"}`,
+			"test",
+			nil,
+			false,
+		},
+		{
+			"not-successful",
+			http.StatusOK,
+			`{"success": false, "message": "invalid parameters"}`,
+			"",
+			nil,
+			true,
+		},
+		{
+			"not-found",
+			http.StatusNotFound,
+			`{"success": false, "message": "not found"}`,
+			"",
+			ErrNotFound,
+			true,
+		},
+		{
+			"unauthorized-html",
+			http.StatusUnauthorized,
+			`<html><body>Unauthorized</body></html>`,
+			"",
+			ErrUnauthorized,
+			true,
+		},
+		{
+			"forbidden",
+			http.StatusForbidden,
+			`{"success": false, "message": "forbidden"}`,
+			"",
+			ErrForbidden,
+			true,
+		},
+		{
+			"rate-limited",
+			http.StatusTooManyRequests,
+			``,
+			"",
+			ErrRateLimited,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			var r response
+			err := decodeResponse("test", resp, &r)
+
+			var apiErr *APIError
+			if errors.As(err, &apiErr) != tt.wantAPIErr {
+				t.Fatalf("decodeResponse() error = %v, wantAPIErr %v", err, tt.wantAPIErr)
+			}
+			if tt.wantAPIErr && string(apiErr.Body) != tt.body {
+				t.Errorf("decodeResponse() body = %s, want %s", apiErr.Body, tt.body)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("decodeResponse() error = %v, want %v", err, tt.wantErr)
+			}
+			if r.Result != tt.want {
+				t.Errorf("decodeResponse() got = %v, want %v", r.Result, tt.want)
+			}
+		})
+	}
+}
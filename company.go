@@ -0,0 +1,177 @@
+package bonusly
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Company represents the company the token belongs to, including its configuration.
+type Company struct {
+	// Id of the company.
+	Id string `json:"id"`
+	// Name of the company.
+	Name string `json:"name"`
+	// CreatedAt is the time the company was created.
+	CreatedAt time.Time `json:"created_at"`
+	// CompanyHashtags are the hashtags (company values) that can be used for bonuses, for example "#teamwork".
+	CompanyHashtags []string `json:"company_hashtags"`
+	// HashtagRequired is true if every bonus must have a hashtag.
+	HashtagRequired bool `json:"hashtag_required"`
+	// GiveAmounts are the amounts users are allowed to give.
+	GiveAmounts []int `json:"give_amounts"`
+	// DefaultMonthlyAllowance is the number of points every user gets to give each month.
+	DefaultMonthlyAllowance int `json:"default_monthly_allowance"`
+	// CurrencyName is the singular name of the company currency, for example "point".
+	CurrencyName string `json:"currency_name"`
+	// CurrencyNamePlural is the plural name of the company currency, for example "points".
+	CurrencyNamePlural string `json:"currency_name_plural"`
+	// PointValueInUsd is the value of a single point in US dollars.
+	PointValueInUsd float64 `json:"point_value_in_usd"`
+	// CustomProperties are the definitions of the custom properties users can have, for example "department".
+	CustomProperties []CustomPropertyDefinition `json:"custom_properties"`
+	// Features are the feature flags of the company, for example "achievements" or "redemptions".
+	Features map[string]bool `json:"features"`
+}
+
+// CustomPropertyDefinition represents a custom property configured for a company.
+type CustomPropertyDefinition struct {
+	// Name of the custom property, for example "department".
+	Name string `json:"name"`
+	// Values are the known values of the custom property, for example "marketing" or "sales".
+	Values []string `json:"values"`
+}
+
+// GetCompanyOutput represents the output of the "Retrieve a Company" operation.
+type GetCompanyOutput struct {
+	// Company is the company the token belongs to.
+	Company Company
+}
+
+// GetCompany returns the company the token belongs to.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/company/retrieve-a-company
+func (c *Client) GetCompany(ctx context.Context) (*GetCompanyOutput, error) {
+	u := fmt.Sprintf("%s/companies/show", c.endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result Company `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("get company", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetCompanyOutput{Company: r.Result}, nil
+}
+
+// UpdateCompanyInput represents the input of the "Update a Company" operation.
+//
+// Only fields that are set (non-nil) are updated.
+type UpdateCompanyInput struct {
+	// Name of the company (optional).
+	Name *string
+	// CompanyHashtags are the hashtags (company values) that can be used for bonuses (optional). Set it to an empty
+	// slice to remove all hashtags.
+	CompanyHashtags *[]string
+	// HashtagRequired defines if every bonus must have a hashtag (optional).
+	HashtagRequired *bool
+	// GiveAmounts are the amounts users are allowed to give (optional). Set it to an empty slice to remove all give
+	// amounts.
+	GiveAmounts *[]int
+	// DefaultMonthlyAllowance is the number of points every user gets to give each month (optional).
+	DefaultMonthlyAllowance *int
+	// CurrencyName is the singular name of the company currency (optional).
+	CurrencyName *string
+	// CurrencyNamePlural is the plural name of the company currency (optional).
+	CurrencyNamePlural *string
+}
+
+// UpdateCompanyOutput represents the output of the "Update a Company" operation.
+type UpdateCompanyOutput struct {
+	// Company is the updated company.
+	Company Company
+}
+
+type updateCompanyBody struct {
+	Name                    *string   `json:"name,omitempty"`
+	CompanyHashtags         *[]string `json:"company_hashtags,omitempty"`
+	HashtagRequired         *bool     `json:"hashtag_required,omitempty"`
+	GiveAmounts             *[]int    `json:"give_amounts,omitempty"`
+	DefaultMonthlyAllowance *int      `json:"default_monthly_allowance,omitempty"`
+	CurrencyName            *string   `json:"currency_name,omitempty"`
+	CurrencyNamePlural      *string   `json:"currency_name_plural,omitempty"`
+}
+
+// UpdateCompany updates the company the token belongs to. This operation requires an admin token.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/company/update-a-company
+func (c *Client) UpdateCompany(ctx context.Context, params *UpdateCompanyInput) (*UpdateCompanyOutput, error) {
+	if params == nil {
+		return nil, ErrMissingParams
+	}
+
+	body := updateCompanyBody{
+		Name:                    params.Name,
+		CompanyHashtags:         params.CompanyHashtags,
+		HashtagRequired:         params.HashtagRequired,
+		GiveAmounts:             params.GiveAmounts,
+		DefaultMonthlyAllowance: params.DefaultMonthlyAllowance,
+		CurrencyName:            params.CurrencyName,
+		CurrencyNamePlural:      params.CurrencyNamePlural,
+	}
+
+	// A nil slice is encoded as null, but an empty list is needed to remove all values.
+	if body.CompanyHashtags != nil && *body.CompanyHashtags == nil {
+		body.CompanyHashtags = &[]string{}
+	}
+	if body.GiveAmounts != nil && *body.GiveAmounts == nil {
+		body.GiveAmounts = &[]int{}
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/companies/update", c.endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result Company `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("update company", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateCompanyOutput{Company: r.Result}, nil
+}
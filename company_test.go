@@ -0,0 +1,108 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClient_GetCompany(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/companies/show" {
+			t.Errorf("GetCompany() request = %v %v", r.Method, r.URL.Path)
+		}
+
+		_, _ = w.Write([]byte(`{
+			"success": true,
+			"result": {
+				"id": "1",
+				"name": "Example Corp",
+				"company_hashtags": ["#teamwork", "#innovation"],
+				"give_amounts": [5, 10, 25],
+				"default_monthly_allowance": 100,
+				"currency_name": "point",
+				"currency_name_plural": "points",
+				"custom_properties": [{"name": "department", "values": ["marketing", "sales"]}],
+				"features": {"achievements": true, "redemptions": false}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	got, err := client.GetCompany(context.TODO())
+	if err != nil {
+		t.Fatalf("GetCompany() error = %v", err)
+	}
+
+	want := Company{
+		Id:                      "1",
+		Name:                    "Example Corp",
+		CompanyHashtags:         []string{"#teamwork", "#innovation"},
+		GiveAmounts:             []int{5, 10, 25},
+		DefaultMonthlyAllowance: 100,
+		CurrencyName:            "point",
+		CurrencyNamePlural:      "points",
+		CustomProperties:        []CustomPropertyDefinition{{Name: "department", Values: []string{"marketing", "sales"}}},
+		Features:                map[string]bool{"achievements": true, "redemptions": false},
+	}
+	if !reflect.DeepEqual(got.Company, want) {
+		t.Errorf("GetCompany() got = %+v, want %+v", got.Company, want)
+	}
+}
+
+func TestClient_UpdateCompany(t *testing.T) {
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/companies/update" {
+			t.Errorf("UpdateCompany() request = %v %v", r.Method, r.URL.Path)
+		}
+
+		body, _ = ioutil.ReadAll(r.Body)
+
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": "1", "name": "New Corp"}}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	name := "New Corp"
+	allowance := 0
+	hashtags := []string{"#teamwork"}
+
+	tests := []struct {
+		name   string
+		params *UpdateCompanyInput
+		want   string
+	}{
+		{"fields", &UpdateCompanyInput{Name: &name, DefaultMonthlyAllowance: &allowance}, `{"name":"New Corp","default_monthly_allowance":0}`},
+		{"set-list", &UpdateCompanyInput{CompanyHashtags: &hashtags}, `{"company_hashtags":["#teamwork"]}`},
+		{"clear-lists", &UpdateCompanyInput{CompanyHashtags: &[]string{}, GiveAmounts: new([]int)}, `{"company_hashtags":[],"give_amounts":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.UpdateCompany(context.TODO(), tt.params)
+			if err != nil {
+				t.Fatalf("UpdateCompany() error = %v", err)
+			}
+
+			if string(body) != tt.want {
+				t.Errorf("UpdateCompany() body = %s, want %s", body, tt.want)
+			}
+
+			if got.Company.Name != name {
+				t.Errorf("UpdateCompany() got = %v, want %v", got.Company.Name, name)
+			}
+		})
+	}
+
+	if _, err := client.UpdateCompany(context.TODO(), nil); !errors.Is(err, ErrMissingParams) {
+		t.Errorf("UpdateCompany() error = %v, want %v", err, ErrMissingParams)
+	}
+}
@@ -0,0 +1,88 @@
+package bonusly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrNotFound is reported by an *APIError if the requested resource does not exist (HTTP 404).
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized is reported by an *APIError if the token is missing or invalid (HTTP 401).
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is reported by an *APIError if the token is not allowed to perform the operation (HTTP 403).
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited is reported by an *APIError if the Bonus.ly REST API rejected the request because too many
+	// requests were sent (HTTP 429).
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrMissingParams is returned by operations that require params if the params are nil.
+	ErrMissingParams = errors.New("params missing")
+)
+
+// APIError is returned by all operations if the Bonus.ly REST API responded with an error.
+//
+// Use errors.As to get access to the details of the error, or errors.Is with one of the sentinel errors
+// (ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited) to check for a specific kind of error.
+type APIError struct {
+	// Operation is the name of the operation that failed, for example "list users".
+	Operation string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message returned by the Bonus.ly REST API. It is empty if the response did not contain a
+	// message, for example because the response body was not JSON.
+	Message string
+	// Method is the HTTP method of the failed request.
+	Method string
+	// URL is the URL of the failed request.
+	URL string
+	// Body is the raw body of the response.
+	Body []byte
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s: %s (status %d)", e.Operation, msg, e.StatusCode)
+}
+
+// Is reports whether the APIError matches the given sentinel error based on the HTTP status code.
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	default:
+		return false
+	}
+}
+
+// newAPIError returns a new *APIError for the operation op based on the given response, raw response body and API
+// message.
+func newAPIError(op string, resp *http.Response, body []byte, message string) *APIError {
+	e := &APIError{
+		Operation:  op,
+		StatusCode: resp.StatusCode,
+		Message:    message,
+		Body:       body,
+	}
+
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			e.URL = resp.Request.URL.String()
+		}
+	}
+
+	return e
+}
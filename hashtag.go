@@ -0,0 +1,125 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalidHashtag is returned if a hashtag is not one of the hashtags (company values) configured for the
+	// company.
+	ErrInvalidHashtag = errors.New("invalid hashtag")
+)
+
+// WithHashtagValidation enables the validation of hashtags before a bonus is created.
+//
+// If enabled, the "Create a Bonus" operation retrieves the hashtags (company values) configured for the company and
+// returns an error that matches ErrInvalidHashtag, without creating the bonus, if one of the hashtags of the
+// CreateBonusInput is not configured.
+func WithHashtagValidation() ClientOption {
+	return func(c *Client) {
+		c.validateHashtags = true
+	}
+}
+
+// ValidateHashtags checks that all given hashtags are configured as hashtags (company values) for the company. The
+// hashtags are normalized before they are validated and are compared case-insensitive.
+//
+// If at least one hashtag is not configured, the returned error matches ErrInvalidHashtag.
+func (c *Client) ValidateHashtags(ctx context.Context, hashtags []string) error {
+	out, err := c.GetCompany(ctx)
+	if err != nil {
+		return err
+	}
+
+	return validateHashtags(normalizeHashtags(hashtags), out.Company.CompanyHashtags)
+}
+
+// validateHashtags returns an error that matches ErrInvalidHashtag if one of the hashtags is not in the list of
+// allowed hashtags.
+func validateHashtags(hashtags []string, allowed []string) error {
+	known := make(map[string]bool, len(allowed))
+	for _, h := range normalizeHashtags(allowed) {
+		known[strings.ToLower(h)] = true
+	}
+
+	var invalid []string
+	for _, h := range hashtags {
+		if !known[strings.ToLower(h)] {
+			invalid = append(invalid, h)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidHashtag, strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
+
+// normalizeHashtags returns the normalized hashtags. Empty hashtags and duplicates are removed.
+func normalizeHashtags(hashtags []string) []string {
+	var normalized []string
+
+	seen := make(map[string]bool, len(hashtags))
+	for _, h := range hashtags {
+		n := normalizeHashtag(h)
+		if n == "" || seen[strings.ToLower(n)] {
+			continue
+		}
+
+		seen[strings.ToLower(n)] = true
+		normalized = append(normalized, n)
+	}
+
+	return normalized
+}
+
+// normalizeHashtag returns the hashtag with a single leading "#" and without any whitespace. If the hashtag is empty,
+// an empty string is returned.
+func normalizeHashtag(hashtag string) string {
+	h := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, hashtag)
+
+	h = strings.TrimLeft(h, "#")
+	if h == "" {
+		return ""
+	}
+
+	return "#" + h
+}
+
+// appendHashtags appends the hashtags to the reason. Hashtags that are already part of the reason are not appended
+// again.
+func appendHashtags(reason string, hashtags []string) string {
+	existing := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(reason), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(".,;:!?()", r)
+	}) {
+		existing[word] = true
+	}
+
+	var b strings.Builder
+	b.WriteString(reason)
+
+	for _, h := range hashtags {
+		if existing[strings.ToLower(h)] {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(h)
+	}
+
+	return b.String()
+}
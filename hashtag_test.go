@@ -0,0 +1,103 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func Test_normalizeHashtags(t *testing.T) {
+	tests := []struct {
+		name     string
+		hashtags []string
+		want     []string
+	}{
+		{"nil", nil, nil},
+		{"leading-hash", []string{"#teamwork"}, []string{"#teamwork"}},
+		{"missing-hash", []string{"teamwork"}, []string{"#teamwork"}},
+		{"multiple-hashes", []string{"##teamwork"}, []string{"#teamwork"}},
+		{"whitespace", []string{" # team work "}, []string{"#teamwork"}},
+		{"empty", []string{"", "#", " "}, nil},
+		{"duplicates", []string{"#teamwork", "Teamwork", "#innovation"}, []string{"#teamwork", "#innovation"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeHashtags(tt.hashtags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeHashtags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateHashtags(t *testing.T) {
+	allowed := []string{"#teamwork", "innovation"}
+
+	tests := []struct {
+		name     string
+		hashtags []string
+		wantErr  bool
+	}{
+		{"none", nil, false},
+		{"valid", []string{"#teamwork", "#innovation"}, false},
+		{"valid-case-insensitive", []string{"#TeamWork"}, false},
+		{"invalid", []string{"#teamwork", "#synergy"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHashtags(tt.hashtags, allowed)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateHashtags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidHashtag) {
+				t.Errorf("validateHashtags() error = %v, want %v", err, ErrInvalidHashtag)
+			}
+		})
+	}
+}
+
+func TestClient_CreateBonusHashtagValidation(t *testing.T) {
+	bonusCreated := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/companies/show":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"company_hashtags": ["#teamwork"]}}`))
+		case "/bonuses":
+			bonusCreated = true
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": "123"}}`))
+		default:
+			t.Errorf("unexpected request to %v", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)), WithHashtagValidation())
+
+	_, err := client.CreateBonus(context.TODO(), &CreateBonusInput{
+		GiverEmail: "frodo@example.com",
+		Receivers:  []string{"bilbo@example.com"},
+		Amount:     10,
+		Hashtags:   []string{"synergy"},
+	})
+	if !errors.Is(err, ErrInvalidHashtag) {
+		t.Errorf("CreateBonus() error = %v, want %v", err, ErrInvalidHashtag)
+	}
+	if bonusCreated {
+		t.Errorf("CreateBonus() created bonus with invalid hashtag")
+	}
+
+	_, err = client.CreateBonus(context.TODO(), &CreateBonusInput{
+		GiverEmail: "frodo@example.com",
+		Receivers:  []string{"bilbo@example.com"},
+		Amount:     10,
+		Hashtags:   []string{"teamwork"},
+	})
+	if err != nil {
+		t.Errorf("CreateBonus() error = %v", err)
+	}
+	if !bonusCreated {
+		t.Errorf("CreateBonus() did not create bonus with valid hashtag")
+	}
+}
@@ -0,0 +1,205 @@
+package bonusly
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the number of requests the bonusly.Client sends to the Bonus.ly REST API.
+//
+// The limit is implemented as a token bucket that is shared by all operations of the client. The bucket is refilled
+// with requestsPerSecond tokens per second and holds at most burst tokens. If no token is available, requests wait
+// until a token becomes available or the context of the request is done.
+//
+// The limiter also adapts to the rate limit information sent by the Bonus.ly REST API: If the API reports that no
+// requests are remaining (X-RateLimit-Remaining and X-RateLimit-Reset headers) or rejects a request with HTTP 429 and
+// a Retry-After header, all requests are paused until the limit is reset. If the pause is longer than the maximum
+// pause (WithMaxRateLimitPause), requests fail immediately with an error wrapping ErrRateLimited instead of waiting.
+//
+// A requestsPerSecond value of 0 or less disables the rate limit.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// DefaultMaxRateLimitPause is the default maximum time requests wait for a pause requested by the Bonus.ly REST API.
+const DefaultMaxRateLimitPause = 30 * time.Second
+
+// WithMaxRateLimitPause sets the maximum time requests wait if the Bonus.ly REST API asks to pause all requests (see
+// WithRateLimit). If the requested pause is longer, requests fail immediately with an error wrapping ErrRateLimited.
+// A value of 0 or less waits for the whole pause, which can block requests for hours.
+//
+// Default: DefaultMaxRateLimitPause
+func WithMaxRateLimitPause(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRateLimitPause = d
+	}
+}
+
+// rateLimiter is a token bucket rate limiter that is safe for concurrent use.
+type rateLimiter struct {
+	mu sync.Mutex
+
+	// rate is the number of tokens added to the bucket per second.
+	rate float64
+	// burst is the maximum number of tokens in the bucket.
+	burst float64
+	// tokens is the number of currently available tokens. A negative number means tokens are already reserved by
+	// waiting requests.
+	tokens float64
+	// last is the time the tokens were last updated.
+	last time.Time
+	// pausedUntil is the time until which no requests are allowed, as reported by the Bonus.ly REST API.
+	pausedUntil time.Time
+}
+
+// newRateLimiter returns a new rateLimiter with a full bucket. If rate is 0 or less, nil is returned.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// wait blocks until the request is allowed to be sent or the context is done. If the Bonus.ly REST API paused all
+// requests for longer than maxPause, an error wrapping ErrRateLimited is returned immediately.
+func (l *rateLimiter) wait(ctx context.Context, maxPause time.Duration) error {
+	if l == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	if paused := l.paused(now); maxPause > 0 && paused > maxPause {
+		return fmt.Errorf("%w: requests are paused for %s, which exceeds the maximum pause of %s",
+			ErrRateLimited, paused.Round(time.Second), maxPause)
+	}
+
+	d := l.reserve(now)
+	if d <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && now.Add(d).After(deadline) {
+		l.release()
+		return context.DeadlineExceeded
+	}
+
+	err := sleep(ctx, d)
+	if err != nil {
+		l.release()
+		return err
+	}
+
+	return nil
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to wait before the token can be used.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	l.tokens--
+
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+
+	if paused := l.pausedUntil.Sub(now); paused > d {
+		d = paused
+	}
+
+	return d
+}
+
+// paused returns how long requests are paused by the Bonus.ly REST API.
+func (l *rateLimiter) paused(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.pausedUntil.Sub(now)
+}
+
+// release returns a reserved token to the bucket.
+func (l *rateLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = math.Min(l.tokens+1, l.burst)
+}
+
+// refill adds the tokens accumulated since the last update. Must be called with the lock held.
+func (l *rateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens = math.Min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.burst)
+	}
+
+	l.last = now
+}
+
+// update adapts the limiter to the rate limit information in the response headers.
+func (l *rateLimiter) update(resp *http.Response) {
+	if l == nil || resp == nil {
+		return
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			l.pause(now.Add(d))
+		}
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining > 0 {
+		return
+	}
+
+	if reset, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now); ok {
+		l.pause(reset)
+	}
+}
+
+// pause blocks all requests until the given time. Must be called with the lock held.
+func (l *rateLimiter) pause(until time.Time) {
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// parseRateLimitReset parses the value of a X-RateLimit-Reset header, which can either be a unix timestamp or a number
+// of seconds until the rate limit is reset.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+
+	// Values that are smaller than a day are interpreted as seconds, everything else as unix timestamp.
+	if reset < 24*60*60 {
+		return now.Add(time.Duration(reset) * time.Second), true
+	}
+
+	return time.Unix(reset, 0), true
+}
@@ -0,0 +1,144 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := newRateLimiter(2, 2)
+
+	want := []time.Duration{0, 0, 500 * time.Millisecond, time.Second}
+	for i, w := range want {
+		if got := l.reserve(now); got != w {
+			t.Errorf("reserve() #%d = %v, want %v", i, got, w)
+		}
+	}
+
+	// After two seconds the four reserved tokens are paid off and the next request can be sent right away.
+	if got := l.reserve(now.Add(2 * time.Second)); got != 0 {
+		t.Errorf("reserve() after refill = %v, want %v", got, 0)
+	}
+}
+
+func TestRateLimiter_update(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		paused bool
+	}{
+		{
+			"no-headers",
+			http.StatusOK,
+			http.Header{},
+			false,
+		},
+		{
+			"remaining",
+			http.StatusOK,
+			http.Header{"X-Ratelimit-Remaining": {"10"}, "X-Ratelimit-Reset": {"30"}},
+			false,
+		},
+		{
+			"exhausted",
+			http.StatusOK,
+			http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"30"}},
+			true,
+		},
+		{
+			"too-many-requests",
+			http.StatusTooManyRequests,
+			http.Header{"Retry-After": {"30"}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(100, 100)
+			l.update(&http.Response{StatusCode: tt.status, Header: tt.header})
+
+			got := l.reserve(time.Now()) > 0
+			if got != tt.paused {
+				t.Errorf("update() paused = %v, want %v", got, tt.paused)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_waitContext(t *testing.T) {
+	l := newRateLimiter(0.1, 1)
+
+	err := l.wait(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = l.wait(ctx, 0)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRateLimiter_waitMaxPause(t *testing.T) {
+	tests := []struct {
+		name  string
+		reset string
+		want  error
+	}{
+		{"short", "1", nil},
+		{"seconds", "80000", ErrRateLimited},
+		{"timestamp", strconv.FormatInt(time.Now().Add(72*time.Hour).Unix(), 10), ErrRateLimited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(100, 100)
+			l.update(&http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {tt.reset}},
+			})
+
+			start := time.Now()
+
+			err := l.wait(context.Background(), 5*time.Second)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("wait() error = %v, want %v", err, tt.want)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("wait() took %v", elapsed)
+			}
+		})
+	}
+}
+
+func Test_parseRateLimitReset(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Time
+		wantOk bool
+	}{
+		{"empty", "", time.Time{}, false},
+		{"seconds", "60", now.Add(time.Minute), true},
+		{"unix", "1646136060", time.Unix(1646136060, 0), true},
+		{"invalid", "later", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRateLimitReset(tt.value, now)
+			if ok != tt.wantOk || !got.Equal(tt.want) {
+				t.Errorf("parseRateLimitReset() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package bonusly
 
 import (
+	"bytes"
+	"container/list"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -93,6 +97,8 @@ func (c *Client) ListRedemptions(ctx context.Context, params *ListRedemptionsInp
 	q.Add("limit", strconv.Itoa(params.Limit))
 	q.Add("skip", strconv.Itoa(params.Skip))
 
+	u.RawQuery = q.Encode()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, err
@@ -103,7 +109,111 @@ func (c *Client) ListRedemptions(ctx context.Context, params *ListRedemptionsInp
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
+	type response struct {
+		baseAPIResponse
+
+		Result []Redemption `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("list redemptions", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListRedemptionsOutput{Redemptions: r.Result}, nil
+}
+
+// ListUserRedemptionsInput represents the input of the "Users Redemptions" operation.
+type ListUserRedemptionsInput struct {
+	// UserId is the id of the user to list the redemptions for.
+	UserId string
+	// Limit is the maximum number of redemptions to return.
+	Limit int
+	// Skip is the number of redemptions to skip, used for pagination.
+	Skip int
+}
+
+// ListUserRedemptionsOutput represents the output of the "Users Redemptions" operation.
+type ListUserRedemptionsOutput struct {
+	// Redemptions is a slice of all found redemptions. If no redemptions are found the slice will be empty.
+	Redemptions []Redemption
+}
+
+// ListUserRedemptionsPaginatorClient is the client interface required by the ListUserRedemptionsPaginator.
+type ListUserRedemptionsPaginatorClient interface {
+	ListUserRedemptions(context.Context, *ListUserRedemptionsInput) (*ListUserRedemptionsOutput, error)
+}
+
+// ListUserRedemptionsPaginator is a paginator for the "Users Redemptions" operation.
+type ListUserRedemptionsPaginator struct {
+	client          ListUserRedemptionsPaginatorClient
+	params          *ListUserRedemptionsInput
+	firstPage       bool
+	offset          int
+	lastResultCount int
+}
+
+// NewListUserRedemptionsPaginator returns a new ListUserRedemptionsPaginator. The params must contain the id of the
+// user. If the limit of params is not set, a limit of 100 redemptions per page is used.
+func NewListUserRedemptionsPaginator(client ListUserRedemptionsPaginatorClient, params *ListUserRedemptionsInput) *ListUserRedemptionsPaginator {
+	if params == nil {
+		params = &ListUserRedemptionsInput{}
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 100
+	}
+
+	return &ListUserRedemptionsPaginator{
+		client:    client,
+		params:    params,
+		firstPage: true,
+	}
+}
+
+// HasMorePages returns true if more pages can be retrieved.
+func (p *ListUserRedemptionsPaginator) HasMorePages() bool {
+	return p.firstPage || p.lastResultCount >= p.params.Limit
+}
+
+// NextPage retrieves the next page of redemptions.
+func (p *ListUserRedemptionsPaginator) NextPage(ctx context.Context) (*ListUserRedemptionsOutput, error) {
+	p.firstPage = false
+	p.params.Skip = p.offset
+
+	output, err := p.client.ListUserRedemptions(ctx, p.params)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lastResultCount = len(output.Redemptions)
+	p.offset += p.lastResultCount
+
+	return output, nil
+}
+
+// ListUserRedemptions returns a list of redemptions of a single user.
+//
+// To retrieve all redemptions of the user use the ListUserRedemptionsPaginator.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/redemptions
+func (c *Client) ListUserRedemptions(ctx context.Context, params *ListUserRedemptionsInput) (*ListUserRedemptionsOutput, error) {
+	if params == nil || params.UserId == "" {
+		return nil, ErrMissingUserId
+	}
+
+	u, err := newListUserRedemptionsURL(c.endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -115,16 +225,35 @@ func (c *Client) ListRedemptions(ctx context.Context, params *ListRedemptionsInp
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("list user redemptions", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUserRedemptionsOutput{Redemptions: r.Result}, nil
+}
+
+// newListUserRedemptionsURL returns the URL to get a list of redemptions of a user (ListUserRedemptions) based on the
+// provided endpoint and params. If the URL can not be created a non-nil error is returned and the URL is nil.
+func newListUserRedemptionsURL(endpoint Endpoint, params *ListUserRedemptionsInput) (*url.URL, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/users/%s/redemptions", endpoint, params.UserId))
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("list redemptions: %v", r.Message)
+	q := u.Query()
+
+	if params.Limit > 0 {
+		q.Add("limit", strconv.Itoa(params.Limit))
 	}
 
-	return &ListRedemptionsOutput{Redemptions: r.Result}, nil
+	if params.Skip > 0 {
+		q.Add("skip", strconv.Itoa(params.Skip))
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u, nil
 }
 
 type GetRedemptionInput struct {
@@ -172,11 +301,141 @@ func (c *Client) GetRedemption(ctx context.Context, params *GetRedemptionInput)
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
+	type response struct {
+		baseAPIResponse
+
+		Result GetRedemptionRedemption `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("get redemption", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetRedemptionOutput{Redemption: r.Result}, nil
+}
+
+var (
+	ErrMissingDenominationId = errors.New("missing denomination id")
+	// ErrUnknownDenomination is returned by CreateRedemption if the denomination does not belong to any reward
+	// available to the user.
+	ErrUnknownDenomination = errors.New("unknown denomination")
+	// ErrInsufficientBalance is returned by CreateRedemption if the earning balance of the user is smaller than the
+	// price of the denomination.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrRedemptionInProgress is returned by CreateRedemption if another redemption with the same idempotency key is
+	// still being created.
+	ErrRedemptionInProgress = errors.New("redemption with the same idempotency key in progress")
+	// ErrRedemptionOutcomeUnknown is returned by CreateRedemption if an earlier redemption with the same idempotency
+	// key failed in a way that leaves open whether the redemption was created, for example because the connection was
+	// lost before the response arrived.
+	ErrRedemptionOutcomeUnknown = errors.New("outcome of redemption with the same idempotency key unknown")
+)
+
+// CreateRedemptionInput represents the input of the "Create a Redemption" operation.
+type CreateRedemptionInput struct {
+	// UserId is the id of the user redeeming the points.
+	UserId string
+	// DenominationId is the id of the denomination to redeem, see RewardDenomination.Id.
+	DenominationId string
+	// GifteeEmail is the email of the person receiving the reward, if the reward is a gift (optional).
+	GifteeEmail string
+	// IdempotencyKey prevents the same redemption from being created twice by the client (optional). If a redemption
+	// with the same key was already created by the client within the last 24 hours, the existing redemption is
+	// returned and no new redemption is created. If the outcome of an earlier redemption with the same key is unknown,
+	// ErrRedemptionOutcomeUnknown is returned. The client remembers at most 1000 redemptions. The key is also sent to
+	// the Bonus.ly REST API in the Idempotency-Key header, but the API does not document support for it.
+	IdempotencyKey string
+}
+
+// CreateRedemptionOutput represents the output of the "Create a Redemption" operation.
+type CreateRedemptionOutput struct {
+	// Redemption is the created redemption.
+	Redemption GetRedemptionRedemption
+}
+
+type createRedemptionBody struct {
+	DenominationId string `json:"denomination_id"`
+	GifteeEmail    string `json:"giftee_email,omitempty"`
+}
+
+// CreateRedemption redeems points of a user for a reward denomination.
+//
+// Before the redemption is created, CreateRedemption checks that the denomination belongs to a reward available to
+// the user and that the earning balance of the user covers the price of the denomination. Otherwise,
+// ErrUnknownDenomination or ErrInsufficientBalance is returned.
+//
+// If the redemption has an idempotency key and the request to create it fails without a response from the Bonus.ly
+// REST API, or with a server error, the redemption might have been created anyway. The error is returned and later
+// calls with the same key return ErrRedemptionOutcomeUnknown, so the redemption is not created twice. Check the
+// redemptions of the user (ListUserRedemptions) before trying again with a new key.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/create-a-redemption
+func (c *Client) CreateRedemption(ctx context.Context, params *CreateRedemptionInput) (*CreateRedemptionOutput, error) {
+	if params == nil || params.UserId == "" {
+		return nil, ErrMissingUserId
+	}
+
+	if params.DenominationId == "" {
+		return nil, ErrMissingDenominationId
+	}
+
+	if params.IdempotencyKey != "" {
+		r, err := c.redemptionCache().begin(params.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if r != nil {
+			return &CreateRedemptionOutput{Redemption: *r}, nil
+		}
+	}
+
+	r, sent, err := c.createRedemption(ctx, params)
+
+	if params.IdempotencyKey != "" {
+		c.redemptionCache().finish(params.IdempotencyKey, r, sent && !isClientError(err))
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
+	return &CreateRedemptionOutput{Redemption: *r}, nil
+}
+
+// createRedemption checks and creates the redemption described by params. The returned bool is true if the request
+// to create the redemption was sent, even if it failed.
+func (c *Client) createRedemption(ctx context.Context, params *CreateRedemptionInput) (*GetRedemptionRedemption, bool, error) {
+	err := c.checkRedemption(ctx, params)
+	if err != nil {
+		return nil, false, err
+	}
+
+	b, err := json.Marshal(createRedemptionBody{
+		DenominationId: params.DenominationId,
+		GifteeEmail:    params.GifteeEmail,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	u := fmt.Sprintf("%s/users/%s/redemptions", c.endpoint, params.UserId)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if params.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", params.IdempotencyKey)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+
 	type response struct {
 		baseAPIResponse
 
@@ -184,14 +443,170 @@ func (c *Client) GetRedemption(ctx context.Context, params *GetRedemptionInput)
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("create redemption", resp, &r)
 	if err != nil {
-		return nil, err
+		return nil, true, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("get redemption: %v", r.Message)
+	return &r.Result, true, nil
+}
+
+// isClientError returns true if err is an *APIError with a 4xx status code. The Bonus.ly REST API rejected such
+// requests without performing the operation.
+func isClientError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode <= 499
+}
+
+// checkRedemption returns an error if the denomination does not belong to a reward available to the user or the user
+// can not afford the denomination.
+func (c *Client) checkRedemption(ctx context.Context, params *CreateRedemptionInput) error {
+	user, err := c.GetUser(ctx, &GetUserInput{Id: params.UserId})
+	if err != nil {
+		return err
 	}
 
-	return &GetRedemptionOutput{Redemption: r.Result}, nil
+	rewards, err := c.ListRewards(ctx, &ListRewardsInput{PersonalizeFor: user.User.Email})
+	if err != nil {
+		return err
+	}
+
+	d := findDenomination(rewards.Rewards, params.DenominationId)
+	if d == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownDenomination, params.DenominationId)
+	}
+
+	if d.Price > user.User.EarningBalance {
+		return fmt.Errorf("%w: %s costs %d points, but user %s has only %d points",
+			ErrInsufficientBalance, d.Name, d.Price, params.UserId, user.User.EarningBalance)
+	}
+
+	return nil
+}
+
+// findDenomination returns the denomination with the given id. If no reward has such a denomination, nil is returned.
+func findDenomination(rewards []ListRewardsReward, id string) *RewardDenomination {
+	for i := range rewards {
+		for j := range rewards[i].Denominations {
+			if rewards[i].Denominations[j].Id == id {
+				return &rewards[i].Denominations[j]
+			}
+		}
+	}
+
+	return nil
+}
+
+const (
+	// redemptionCacheSize is the maximum number of created redemptions remembered by the client.
+	redemptionCacheSize = 1000
+	// redemptionCacheTTL is the time a created redemption is remembered by the client.
+	redemptionCacheTTL = 24 * time.Hour
+)
+
+// redemptionCache remembers the redemptions created with an idempotency key. It is safe for concurrent use.
+//
+// Redemptions in progress are always remembered. Created redemptions and redemptions with an unknown outcome are
+// remembered for the ttl, and if more than capacity redemptions were remembered, the least recently used redemption
+// is removed. Removed keys can be used to create a redemption again.
+type redemptionCache struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// inProgress contains the keys of the redemptions that are currently created.
+	inProgress map[string]struct{}
+	// order contains the created redemptions, sorted from the most to the least recently used.
+	order   *list.List
+	created map[string]*list.Element
+}
+
+type redemptionCacheEntry struct {
+	key string
+	// redemption is the created redemption. It is nil if the outcome of the redemption is unknown.
+	redemption *GetRedemptionRedemption
+	expires    time.Time
+}
+
+// redemptionCache returns the redemption cache of the client, creating it on first use.
+func (c *Client) redemptionCache() *redemptionCache {
+	c.redemptionsOnce.Do(func() {
+		if c.redemptions == nil {
+			c.redemptions = newRedemptionCache(redemptionCacheSize, redemptionCacheTTL)
+		}
+	})
+
+	return c.redemptions
+}
+
+func newRedemptionCache(capacity int, ttl time.Duration) *redemptionCache {
+	return &redemptionCache{
+		capacity:   capacity,
+		ttl:        ttl,
+		now:        time.Now,
+		inProgress: make(map[string]struct{}),
+		order:      list.New(),
+		created:    make(map[string]*list.Element),
+	}
+}
+
+// begin marks the redemption with the given key as in progress. If the redemption was already created, it is returned
+// instead. If the redemption is in progress, ErrRedemptionInProgress is returned, and if the outcome of the redemption
+// is unknown, ErrRedemptionOutcomeUnknown is returned.
+func (c *redemptionCache) begin(key string) (*GetRedemptionRedemption, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.inProgress[key]; exists {
+		return nil, ErrRedemptionInProgress
+	}
+
+	if e, exists := c.created[key]; exists {
+		entry := e.Value.(*redemptionCacheEntry)
+		if c.now().Before(entry.expires) {
+			c.order.MoveToFront(e)
+			if entry.redemption == nil {
+				return nil, ErrRedemptionOutcomeUnknown
+			}
+
+			return entry.redemption, nil
+		}
+
+		c.remove(e)
+	}
+
+	c.inProgress[key] = struct{}{}
+
+	return nil, nil
+}
+
+// finish stores the created redemption for the given key. If the redemption is nil, because it was not created, the
+// key is removed, so the redemption can be tried again, unless unknown is true. Then the key is remembered with an
+// unknown outcome.
+func (c *redemptionCache) finish(key string, r *GetRedemptionRedemption, unknown bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.inProgress, key)
+
+	if r == nil && !unknown {
+		return
+	}
+
+	now := c.now()
+	c.created[key] = c.order.PushFront(&redemptionCacheEntry{key: key, redemption: r, expires: now.Add(c.ttl)})
+
+	for e := c.order.Back(); e != nil && c.order.Len() > c.capacity; e = c.order.Back() {
+		c.remove(e)
+	}
+
+	for e := c.order.Back(); e != nil && !now.Before(e.Value.(*redemptionCacheEntry).expires); e = c.order.Back() {
+		c.remove(e)
+	}
+}
+
+func (c *redemptionCache) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.created, e.Value.(*redemptionCacheEntry).key)
 }
@@ -0,0 +1,333 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_ListRedemptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "/redemptions?limit=10&skip=30"
+		if r.URL.String() != want {
+			t.Errorf("ListRedemptions() URL = %v, want %v", r.URL, want)
+		}
+
+		_, _ = w.Write([]byte(`{"success": true, "result": [{"id": "r1", "amount_in_points": 500}]}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	got, err := client.ListRedemptions(context.TODO(), &ListRedemptionsInput{Limit: 10, Skip: 30})
+	if err != nil {
+		t.Fatalf("ListRedemptions() error = %v", err)
+	}
+
+	if len(got.Redemptions) != 1 || got.Redemptions[0].AmountInPoints != 500 {
+		t.Errorf("ListRedemptions() got = %+v", got.Redemptions)
+	}
+}
+
+type mockListUserRedemptionsClient struct {
+	pages []int
+	c     int
+}
+
+func (m *mockListUserRedemptionsClient) ListUserRedemptions(context.Context, *ListUserRedemptionsInput) (*ListUserRedemptionsOutput, error) {
+	p := m.pages[m.c]
+	m.c++
+
+	return &ListUserRedemptionsOutput{Redemptions: make([]Redemption, p)}, nil
+}
+
+func TestListUserRedemptionsPaginator(t *testing.T) {
+	client := &mockListUserRedemptionsClient{pages: []int{5, 5, 2}}
+
+	var redemptions []Redemption
+
+	paginator := NewListUserRedemptionsPaginator(client, &ListUserRedemptionsInput{UserId: "1", Limit: 5})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		redemptions = append(redemptions, output.Redemptions...)
+	}
+
+	if len(redemptions) != 12 {
+		t.Errorf("ListUserRedemptionsPaginator(), got = %d, want = %d", len(redemptions), 12)
+	}
+}
+
+func Test_newListUserRedemptionsURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *ListUserRedemptionsInput
+		want   string
+	}{
+		{"no-settings", &ListUserRedemptionsInput{UserId: "1"}, fmt.Sprintf("%s/users/1/redemptions", EndpointProduction)},
+		{"limit-skip", &ListUserRedemptionsInput{UserId: "1", Limit: 10, Skip: 20}, fmt.Sprintf("%s/users/1/redemptions?limit=10&skip=20", EndpointProduction)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newListUserRedemptionsURL(EndpointProduction, tt.params)
+			if err != nil {
+				t.Fatalf("newListUserRedemptionsURL() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("newListUserRedemptionsURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_CreateRedemption(t *testing.T) {
+	var created int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/users/1":
+			_, _ = w.Write([]byte(`{"success": true, "result": {"id": "1", "email": "frodo@example.com", "earning_balance": 1000}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rewards":
+			if got := r.URL.Query().Get("personalize_for"); got != "frodo@example.com" {
+				t.Errorf("ListRewards() personalize_for = %v, want %v", got, "frodo@example.com")
+			}
+
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"type": "gift_cards", "name": "Gift Cards", "rewards": [{
+				"name": "Book Store",
+				"denominations": [
+					{"id": "d500", "name": "$5 Book Store", "price": 500},
+					{"id": "d5000", "name": "$50 Book Store", "price": 5000}
+				]
+			}]}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/users/1/redemptions":
+			created++
+
+			if got := r.Header.Get("Idempotency-Key"); got != "key-1" {
+				t.Errorf("CreateRedemption() Idempotency-Key = %v, want %v", got, "key-1")
+			}
+
+			b, _ := ioutil.ReadAll(r.Body)
+			want := `{"denomination_id":"d500","giftee_email":"sam@example.com"}`
+			if string(b) != want {
+				t.Errorf("CreateRedemption() body = %s, want %s", b, want)
+			}
+
+			_, _ = w.Write([]byte(`{"success": true, "result": {
+				"id": "r1",
+				"state": "approved",
+				"claim_url": "https://example.com/claim",
+				"certificate_url": "https://example.com/certificate"
+			}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	params := &CreateRedemptionInput{
+		UserId:         "1",
+		DenominationId: "d500",
+		GifteeEmail:    "sam@example.com",
+		IdempotencyKey: "key-1",
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := client.CreateRedemption(context.TODO(), params)
+		if err != nil {
+			t.Fatalf("CreateRedemption() error = %v", err)
+		}
+
+		r := got.Redemption
+		if r.Id != "r1" || r.State != "approved" || r.ClaimUrl == "" || r.CertificateUrl == "" {
+			t.Errorf("CreateRedemption() got = %+v", r)
+		}
+	}
+
+	if created != 1 {
+		t.Errorf("CreateRedemption() created %d redemptions, want %d", created, 1)
+	}
+
+	tests := []struct {
+		name   string
+		params *CreateRedemptionInput
+		want   error
+	}{
+		{"missing-user", &CreateRedemptionInput{DenominationId: "d500"}, ErrMissingUserId},
+		{"missing-denomination", &CreateRedemptionInput{UserId: "1"}, ErrMissingDenominationId},
+		{"unknown-denomination", &CreateRedemptionInput{UserId: "1", DenominationId: "d1"}, ErrUnknownDenomination},
+		{"insufficient-balance", &CreateRedemptionInput{UserId: "1", DenominationId: "d5000"}, ErrInsufficientBalance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CreateRedemption(context.TODO(), tt.params)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("CreateRedemption() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_CreateRedemptionOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantSent bool
+		wantErr  error
+	}{
+		{"transport-failure", 0, false, ErrRedemptionOutcomeUnknown},
+		{"server-error", http.StatusInternalServerError, false, ErrRedemptionOutcomeUnknown},
+		{"client-error", http.StatusUnprocessableEntity, true, &APIError{StatusCode: http.StatusUnprocessableEntity}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/users/1":
+					_, _ = w.Write([]byte(`{"success": true, "result": {"id": "1", "email": "frodo@example.com", "earning_balance": 1000}}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/rewards":
+					_, _ = w.Write([]byte(`{"success": true, "result": [{"rewards": [{"denominations": [{"id": "d500", "price": 500}]}]}]}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/users/1/redemptions":
+					atomic.AddInt32(&created, 1)
+
+					if tt.status == 0 {
+						// Drop the connection, so the client does not know whether the redemption was created.
+						conn, _, err := w.(http.Hijacker).Hijack()
+						if err != nil {
+							t.Errorf("Hijack() error = %v", err)
+							return
+						}
+						_ = conn.Close()
+						return
+					}
+
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(`{"success": false, "message": "failed"}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+			params := &CreateRedemptionInput{UserId: "1", DenominationId: "d500", IdempotencyKey: "key-1"}
+
+			_, err := client.CreateRedemption(context.TODO(), params)
+			if err == nil {
+				t.Fatalf("CreateRedemption() error = nil, want error")
+			}
+
+			before := atomic.LoadInt32(&created)
+			_, err = client.CreateRedemption(context.TODO(), params)
+			sent := atomic.LoadInt32(&created) > before
+
+			var apiErr *APIError
+			if want, ok := tt.wantErr.(*APIError); ok {
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != want.StatusCode {
+					t.Errorf("CreateRedemption() again error = %v, want status %d", err, want.StatusCode)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateRedemption() again error = %v, want %v", err, tt.wantErr)
+			}
+
+			if sent != tt.wantSent {
+				t.Errorf("CreateRedemption() again sent = %t, want %t", sent, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestClient_redemptionCache(t *testing.T) {
+	var c Client
+
+	if c.redemptionCache() == nil || c.redemptionCache() != c.redemptionCache() {
+		t.Errorf("redemptionCache() must return the same non-nil cache")
+	}
+}
+
+func TestRedemptionCache(t *testing.T) {
+	c := newRedemptionCache(10, time.Hour)
+
+	r, err := c.begin("key")
+	if r != nil || err != nil {
+		t.Fatalf("begin() = %v, %v, want nil, nil", r, err)
+	}
+
+	_, err = c.begin("key")
+	if !errors.Is(err, ErrRedemptionInProgress) {
+		t.Errorf("begin() error = %v, want %v", err, ErrRedemptionInProgress)
+	}
+
+	c.finish("key", nil, false)
+
+	r, err = c.begin("key")
+	if r != nil || err != nil {
+		t.Fatalf("begin() after failure = %v, %v, want nil, nil", r, err)
+	}
+
+	c.finish("key", &GetRedemptionRedemption{Id: "r1"}, false)
+
+	r, err = c.begin("key")
+	if err != nil || r == nil || r.Id != "r1" {
+		t.Errorf("begin() after success = %v, %v", r, err)
+	}
+
+	_, _ = c.begin("unknown")
+	c.finish("unknown", nil, true)
+
+	_, err = c.begin("unknown")
+	if !errors.Is(err, ErrRedemptionOutcomeUnknown) {
+		t.Errorf("begin() after unknown outcome error = %v, want %v", err, ErrRedemptionOutcomeUnknown)
+	}
+}
+
+func TestRedemptionCache_Eviction(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	c := newRedemptionCache(2, time.Hour)
+	c.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, _ = c.begin(key)
+		c.finish(key, &GetRedemptionRedemption{Id: key}, false)
+	}
+
+	if len(c.created) != 2 {
+		t.Errorf("created = %d, want 2", len(c.created))
+	}
+
+	// "a" was evicted, so it can be created again.
+	if r, err := c.begin("a"); r != nil || err != nil {
+		t.Errorf("begin(a) = %v, %v, want nil, nil", r, err)
+	}
+	c.finish("a", nil, false)
+
+	if r, _ := c.begin("c"); r == nil || r.Id != "c" {
+		t.Errorf("begin(c) = %v, want c", r)
+	}
+
+	now = now.Add(time.Hour)
+
+	if r, err := c.begin("c"); r != nil || err != nil {
+		t.Errorf("begin(c) after the TTL = %v, %v, want nil, nil", r, err)
+	}
+	c.finish("c", nil, false)
+
+	if len(c.inProgress) != 0 {
+		t.Errorf("inProgress = %d, want 0", len(c.inProgress))
+	}
+}
@@ -0,0 +1,222 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures if and how the Client retries failed requests.
+//
+// Requests are retried if sending the request failed because of a connection error, or if the Bonus.ly REST API
+// responded with HTTP 429 (Too Many Requests) or a 5xx status code. By default, only idempotent requests (GET, HEAD,
+// OPTIONS, PUT and DELETE) are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a single request, including the first attempt. A value of 1
+	// or less disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the time to wait before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum time to wait between two attempts. A Retry-After header sent by the Bonus.ly REST API
+	// takes precedence over the calculated backoff. If the Retry-After header asks to wait longer than MaxBackoff, the
+	// request is not retried and the response is returned, instead of blocking for an unbounded time. A value of 0
+	// disables the limit.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the backoff grows after every attempt. Values smaller than 1 are treated as 1.
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, by which the backoff is randomly increased or decreased. Jitter avoids
+	// that many clients retry at exactly the same time.
+	Jitter float64
+
+	// RetryNonIdempotent allows retrying non-idempotent requests, like POST requests.
+	//
+	// Be careful when enabling this option: If a request reached the Bonus.ly REST API but the response got lost,
+	// retrying the request will perform the operation a second time. For example, a retried CreateBonus can give
+	// the points of a bonus twice.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is a sensible RetryPolicy that can be used with WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// WithRetryPolicy sets the RetryPolicy used by the bonusly.Client.
+//
+// By default, the bonusly.Client does not retry any requests.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// shouldRetry returns true if the request should be retried, based on the result of the given attempt.
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return req.Context().Err() == nil && !errors.Is(err, ErrRateLimited)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// backoff returns the time to wait after the given attempt. If the response contains a valid Retry-After header its
+// value is used instead of the calculated backoff. If the Retry-After value exceeds MaxBackoff, false is returned and
+// the request must not be retried.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d, p.MaxBackoff <= 0 || d <= p.MaxBackoff
+		}
+	}
+
+	multiplier := math.Max(p.Multiplier, 1)
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d += d * jitter * (2*rand.Float64() - 1) //nolint: gosec
+	}
+
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	return time.Duration(d), true
+}
+
+// do sends the request using send and retries it according to the policy. If the policy is nil, the request is only
+// sent once.
+func (p *RetryPolicy) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := send(req)
+		if !p.shouldRetry(req, resp, err, attempt) {
+			return resp, err
+		}
+
+		wait, ok := p.backoff(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+
+		if resp != nil {
+			drainAndCloseBody(resp)
+		}
+
+		req, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		err = sleep(ctx, wait)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isIdempotent returns true if requests with the given HTTP method can safely be sent multiple times.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which can either be a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	d := t.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
+
+// rewindRequest returns a copy of the request with a fresh body, so the request can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return r, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body = body
+
+	return r, nil
+}
+
+// drainAndCloseBody reads the remaining body of the response and closes it, so the underlying connection can be
+// reused.
+func drainAndCloseBody(resp *http.Response) {
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+	_ = resp.Body.Close()
+}
+
+// sleep waits for the duration d or until the context is done, whichever happens first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
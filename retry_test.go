@@ -0,0 +1,223 @@
+package bonusly
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_DoRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+
+	tests := []struct {
+		name         string
+		method       string
+		policy       *RetryPolicy
+		statusCodes  []int
+		wantAttempts int
+		wantStatus   int
+	}{
+		{
+			"no-policy",
+			http.MethodGet,
+			nil,
+			[]int{http.StatusServiceUnavailable, http.StatusOK},
+			1,
+			http.StatusServiceUnavailable,
+		},
+		{
+			"get-retried",
+			http.MethodGet,
+			&policy,
+			[]int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			3,
+			http.StatusOK,
+		},
+		{
+			"get-max-attempts",
+			http.MethodGet,
+			&policy,
+			[]int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			3,
+			http.StatusBadGateway,
+		},
+		{
+			"get-client-error",
+			http.MethodGet,
+			&policy,
+			[]int{http.StatusBadRequest, http.StatusOK},
+			1,
+			http.StatusBadRequest,
+		},
+		{
+			"post-not-retried",
+			http.MethodPost,
+			&policy,
+			[]int{http.StatusServiceUnavailable, http.StatusOK},
+			1,
+			http.StatusServiceUnavailable,
+		},
+		{
+			"post-retried-opt-in",
+			http.MethodPost,
+			&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryNonIdempotent: true},
+			[]int{http.StatusServiceUnavailable, http.StatusOK},
+			2,
+			http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != "payload" {
+					t.Errorf("Do() attempt %d body = %q, want %q", attempts+1, body, "payload")
+				}
+
+				w.WriteHeader(tt.statusCodes[attempts])
+				attempts++
+			}))
+			defer server.Close()
+
+			client := New(Configuration{Token: "test"})
+			client.retryPolicy = tt.policy
+
+			req, err := http.NewRequest(tt.method, server.URL, bytes.NewReader([]byte("payload")))
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			drainAndCloseBody(resp)
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Do() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("Do() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_DoRetryContextDeadline(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithRetryPolicy(DefaultRetryPolicy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	drainAndCloseBody(resp)
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Do() status = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if attempts != 1 {
+		t.Errorf("Do() attempts = %d, want %d", attempts, 1)
+	}
+}
+
+func TestClient_DoRetryAfterExceedsMaxBackoff(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithRetryPolicy(DefaultRetryPolicy))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	drainAndCloseBody(resp)
+
+	if resp.StatusCode != http.StatusTooManyRequests || attempts != 1 {
+		t.Errorf("Do() status = %d, attempts = %d, want %d and 1", resp.StatusCode, attempts, http.StatusTooManyRequests)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Do() took %v, want no wait", elapsed)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2, Jitter: 0.5}
+
+	tests := []struct {
+		name    string
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"first", 1, 50 * time.Millisecond, 150 * time.Millisecond},
+		{"second", 2, 100 * time.Millisecond, 300 * time.Millisecond},
+		{"capped", 10, time.Second, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := policy.backoff(tt.attempt, nil)
+			if got < tt.min || got > tt.max {
+				t.Errorf("backoff() = %v, want between %v and %v", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "5", 5 * time.Second, true},
+		{"negative", "-5", 0, false},
+		{"http-date", "Tue, 01 Mar 2022 12:00:30 GMT", 30 * time.Second, true},
+		{"http-date-past", "Tue, 01 Mar 2022 11:00:00 GMT", 0, true},
+		{"invalid", "soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("parseRetryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package bonusly
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -111,21 +110,12 @@ func (c *Client) ListRewards(ctx context.Context, params *ListRewardsInput) (*Li
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
-	if err != nil {
-		return nil, err
-	}
-
 	var r listRewardsResponse
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("list rewards", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("list rewards: %v", r.Message)
-	}
-
 	rewards := newRewards(r)
 
 	return &ListRewardsOutput{Rewards: rewards}, nil
@@ -265,11 +255,6 @@ func (c *Client) GetReward(ctx context.Context, params *GetRewardInput) (*GetRew
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
-	if err != nil {
-		return nil, err
-	}
-
 	type response struct {
 		baseAPIResponse
 
@@ -277,14 +262,10 @@ func (c *Client) GetReward(ctx context.Context, params *GetRewardInput) (*GetRew
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("get reward", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("list users: %v", r.Message)
-	}
-
 	return &GetRewardOutput{Reward: r.Result}, nil
 }
@@ -0,0 +1,168 @@
+// Package scim implements a client for the Bonus.ly SCIM 2.0 API, which is used to provision users from an identity
+// provider.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/scim
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	bonusly "github.com/groundfoghub/bonusly-sdk-go"
+)
+
+// MediaType is the media type of SCIM requests and responses.
+const MediaType = "application/scim+json"
+
+// New creates a new Client that can be used to interact with the Bonus.ly SCIM API.
+//
+// The SCIM API requires an admin token.
+func New(cfg bonusly.Configuration, options ...ClientOption) *Client {
+	c := &Client{
+		httpClient:    http.DefaultClient,
+		tokenProvider: bonusly.StaticToken(cfg.Token),
+		endpoint:      EndpointProduction,
+	}
+
+	for _, fn := range options {
+		fn(c)
+	}
+
+	return c
+}
+
+// ClientOption is a functional option to allow overwriting certain configuration options of the Client.
+type ClientOption func(c *Client)
+
+// WithHttpClient sets a new http.Client to be used by the scim.Client.
+func WithHttpClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = client
+	}
+}
+
+// WithEndpoint sets a new endpoint to be used by the scim.Client.
+func WithEndpoint(endpoint Endpoint) ClientOption {
+	return func(c *Client) {
+		c.endpoint = endpoint
+	}
+}
+
+// WithTokenProvider sets the bonusly.TokenProvider used by the scim.Client. It replaces the token of the
+// bonusly.Configuration.
+func WithTokenProvider(provider bonusly.TokenProvider) ClientOption {
+	return func(c *Client) {
+		c.tokenProvider = provider
+	}
+}
+
+// Client implements the methods for the Bonus.ly SCIM API.
+//
+// The Client can be configured through scim.ClientOption when using the scim.New() function.
+type Client struct {
+	// httpClient is the client used for requests to the Bonus.ly SCIM API.
+	//
+	// Default: http.DefaultClient
+	httpClient *http.Client
+
+	// tokenProvider provides the Bonus.ly admin token that is used for requests to the Bonus.ly SCIM API.
+	//
+	// Default: bonusly.StaticToken(Configuration.Token)
+	tokenProvider bonusly.TokenProvider
+
+	// endpoint is the HTTP endpoint the scim.Client sends requests to.
+	//
+	// Default: EndpointProduction
+	endpoint Endpoint
+}
+
+// Endpoint is the Bonus.ly SCIM API endpoint to which requests are sent to.
+type Endpoint string
+
+const (
+	// EndpointProduction is the fully qualified domain name and path of the Bonus.ly SCIM API production endpoint.
+	//
+	// To change the endpoint use the WithEndpoint option when creating a new Client using the New() function.
+	EndpointProduction Endpoint = "https://bonus.ly/api/scim/v2"
+)
+
+// Do sends the request to the Bonus.ly SCIM API and returns the response.
+//
+// Do adds the authentication header and the SCIM media type headers to the request.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	token, err := c.tokenProvider.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("get token: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Accept", MediaType)
+
+	if req.Body != nil {
+		req.Header.Set("Content-Type", MediaType)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// send sends a request with the given method, path and body (which can be nil) and decodes the response into v (which
+// can be nil). The path is relative to the endpoint of the client.
+func (c *Client) send(ctx context.Context, op string, method string, path string, body interface{}, v interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.endpoint, path), r)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(op, resp, v)
+}
+
+// decodeResponse reads and closes the body of the given *http.Response and decodes it into v. If v is nil, the body
+// is discarded.
+//
+// If the response has a non-2xx status code, an *Error for the operation op is returned.
+func decodeResponse(op string, resp *http.Response, v interface{}) error {
+	// Note: ioutil.ReadAll is used instead of io.ReadAll, because our minimum Go version is 1.13.
+	body, err := ioutil.ReadAll(resp.Body)
+	cerr := resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	if cerr != nil {
+		return cerr
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newError(op, resp.StatusCode, body)
+	}
+
+	if v == nil || len(body) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bonusly "github.com/groundfoghub/bonusly-sdk-go"
+)
+
+func TestClient_Error(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantScimType string
+		wantDetail   string
+		wantIs       error
+	}{
+		{
+			"scim-error",
+			http.StatusBadRequest,
+			`{"schemas": ["urn:ietf:params:scim:api:messages:2.0:Error"], "scimType": "invalidFilter", "detail": "Invalid filter", "status": "400"}`,
+			"invalidFilter",
+			"Invalid filter",
+			nil,
+		},
+		{
+			"numeric-status",
+			http.StatusNotFound,
+			`{"schemas": ["urn:ietf:params:scim:api:messages:2.0:Error"], "detail": "User not found", "status": 404}`,
+			"",
+			"User not found",
+			bonusly.ErrNotFound,
+		},
+		{
+			"no-json",
+			http.StatusUnauthorized,
+			`Unauthorized`,
+			"",
+			"",
+			bonusly.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := New(bonusly.Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+			_, err := client.GetUser(context.TODO(), &GetUserInput{Id: "1"})
+
+			var scimErr *Error
+			if !errors.As(err, &scimErr) {
+				t.Fatalf("GetUser() error = %v, want *Error", err)
+			}
+			if scimErr.StatusCode != tt.status || scimErr.ScimType != tt.wantScimType || scimErr.Detail != tt.wantDetail {
+				t.Errorf("GetUser() error = %+v", scimErr)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestClient_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ServiceProviderConfig":
+			_, _ = w.Write([]byte(`{
+				"patch": {"supported": true},
+				"bulk": {"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+				"filter": {"supported": true, "maxResults": 100},
+				"authenticationSchemes": [{"type": "oauthbearertoken", "name": "OAuth Bearer Token", "primary": true}]
+			}`))
+		case "/ResourceTypes":
+			_, _ = w.Write([]byte(`{"totalResults": 1, "Resources": [{
+				"id": "User",
+				"name": "User",
+				"endpoint": "/Users",
+				"schema": "urn:ietf:params:scim:schemas:core:2.0:User",
+				"schemaExtensions": [{"schema": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User", "required": false}]
+			}]}`))
+		case "/Schemas":
+			_, _ = w.Write([]byte(`{"totalResults": 1, "Resources": [{
+				"id": "urn:ietf:params:scim:schemas:core:2.0:User",
+				"name": "User",
+				"attributes": [{"name": "name", "type": "complex", "subAttributes": [{"name": "givenName", "type": "string"}]}]
+			}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := New(bonusly.Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+	ctx := context.TODO()
+
+	spc, err := client.GetServiceProviderConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetServiceProviderConfig() error = %v", err)
+	}
+	cfg := spc.ServiceProviderConfig
+	if !cfg.Patch.Supported || cfg.Bulk.Supported || cfg.Filter.MaxResults != 100 || len(cfg.AuthenticationSchemes) != 1 {
+		t.Errorf("GetServiceProviderConfig() got = %+v", cfg)
+	}
+
+	rts, err := client.ListResourceTypes(ctx)
+	if err != nil {
+		t.Fatalf("ListResourceTypes() error = %v", err)
+	}
+	if len(rts.ResourceTypes) != 1 || rts.ResourceTypes[0].Endpoint != "/Users" || len(rts.ResourceTypes[0].SchemaExtensions) != 1 {
+		t.Errorf("ListResourceTypes() got = %+v", rts.ResourceTypes)
+	}
+
+	schemas, err := client.ListSchemas(ctx)
+	if err != nil {
+		t.Fatalf("ListSchemas() error = %v", err)
+	}
+	if len(schemas.Schemas) != 1 || schemas.Schemas[0].Attributes[0].SubAttributes[0].Name != "givenName" {
+		t.Errorf("ListSchemas() got = %+v", schemas.Schemas)
+	}
+}
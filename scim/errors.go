@@ -0,0 +1,87 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	bonusly "github.com/groundfoghub/bonusly-sdk-go"
+)
+
+// SchemaError is the schema URI of SCIM error responses.
+const SchemaError = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+// Error is returned by all operations if the Bonus.ly SCIM API responded with an error.
+//
+// Use errors.As to get access to the details of the error, or errors.Is with one of the sentinel errors of the
+// bonusly package (bonusly.ErrNotFound, bonusly.ErrUnauthorized, bonusly.ErrForbidden, bonusly.ErrRateLimited) to
+// check for a specific kind of error.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.12
+type Error struct {
+	// Operation is the name of the operation that failed, for example "list users".
+	Operation string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// ScimType is the SCIM detail error keyword, for example "invalidFilter" or "uniqueness". It is empty if the
+	// response did not contain one.
+	ScimType string
+	// Detail is the human-readable description of the error. It is empty if the response did not contain one.
+	Detail string
+	// Body is the raw body of the response.
+	Body []byte
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	if e.ScimType != "" {
+		msg = fmt.Sprintf("%s: %s", e.ScimType, msg)
+	}
+
+	return fmt.Sprintf("%s: %s (status %d)", e.Operation, msg, e.StatusCode)
+}
+
+// Is reports whether the Error matches the given sentinel error of the bonusly package based on the HTTP status code.
+func (e *Error) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return target == bonusly.ErrNotFound
+	case http.StatusUnauthorized:
+		return target == bonusly.ErrUnauthorized
+	case http.StatusForbidden:
+		return target == bonusly.ErrForbidden
+	case http.StatusTooManyRequests:
+		return target == bonusly.ErrRateLimited
+	default:
+		return false
+	}
+}
+
+// newError returns a new *Error for the operation op based on the status code and the raw response body. If the body
+// is a SCIM error response, the SCIM type and detail are taken from the body.
+func newError(op string, statusCode int, body []byte) *Error {
+	e := &Error{
+		Operation:  op,
+		StatusCode: statusCode,
+		Body:       body,
+	}
+
+	var r struct {
+		ScimType string `json:"scimType"`
+		Detail   string `json:"detail"`
+	}
+
+	// The status of a SCIM error response is ignored, since it duplicates the status code and some implementations
+	// send it as a number instead of a string.
+	if json.Unmarshal(body, &r) == nil {
+		e.ScimType = r.ScimType
+		e.Detail = r.Detail
+	}
+
+	return e
+}
@@ -0,0 +1,628 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalidFilter is returned by Parse if the filter expression is not valid.
+	ErrInvalidFilter = errors.New("invalid filter")
+)
+
+// Filter is a SCIM filter expression, used to limit the users returned by ListUsers.
+//
+// Filters are created with the functions of this package, for example Eq, Pr or Not, combined with And and Or, or
+// parsed from a string with Parse. The zero value is the empty filter, which matches all resources.
+//
+//	scim.Eq("userName", "a@b.com").And(scim.Eq("active", true))
+//
+// Values are rendered as JSON, so strings are quoted and escaped correctly. Attribute paths are not escaped and must
+// be valid SCIM attribute paths, for example "userName", "name.familyName" or
+// "urn:ietf:params:scim:schemas:core:2.0:User:userName".
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2.2
+type Filter struct {
+	expr expression
+}
+
+// expression is a node of a filter expression.
+type expression interface {
+	// write writes the expression to the buffer.
+	write(b *bytes.Buffer)
+	// precedence returns the precedence of the expression. Expressions with a lower precedence must be put in
+	// parentheses when used as operand of an expression with a higher precedence.
+	precedence() int
+}
+
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceUnary
+)
+
+// String returns the filter expression as used in the filter query parameter. The empty filter returns an empty
+// string.
+func (f Filter) String() string {
+	if f.expr == nil {
+		return ""
+	}
+
+	var b bytes.Buffer
+	f.expr.write(&b)
+
+	return b.String()
+}
+
+// IsZero returns true if f is the empty filter.
+func (f Filter) IsZero() bool {
+	return f.expr == nil
+}
+
+// And returns a filter that matches if both f and other match. If one of the filters is empty, the other filter is
+// returned.
+func (f Filter) And(other Filter) Filter {
+	return f.logical("and", precedenceAnd, other)
+}
+
+// Or returns a filter that matches if f or other matches. If one of the filters is empty, the other filter is
+// returned.
+func (f Filter) Or(other Filter) Filter {
+	return f.logical("or", precedenceOr, other)
+}
+
+func (f Filter) logical(op string, p int, other Filter) Filter {
+	if f.expr == nil {
+		return other
+	}
+
+	if other.expr == nil {
+		return f
+	}
+
+	return Filter{expr: &logicalExpression{op: op, p: p, left: f.expr, right: other.expr}}
+}
+
+// Eq returns a filter that matches if the attribute is equal to the value.
+func Eq(attr string, value interface{}) Filter {
+	return compare(attr, "eq", value)
+}
+
+// Ne returns a filter that matches if the attribute is not equal to the value.
+func Ne(attr string, value interface{}) Filter {
+	return compare(attr, "ne", value)
+}
+
+// Co returns a filter that matches if the attribute contains the value.
+func Co(attr string, value interface{}) Filter {
+	return compare(attr, "co", value)
+}
+
+// Sw returns a filter that matches if the attribute starts with the value.
+func Sw(attr string, value interface{}) Filter {
+	return compare(attr, "sw", value)
+}
+
+// Ew returns a filter that matches if the attribute ends with the value.
+func Ew(attr string, value interface{}) Filter {
+	return compare(attr, "ew", value)
+}
+
+// Gt returns a filter that matches if the attribute is greater than the value.
+func Gt(attr string, value interface{}) Filter {
+	return compare(attr, "gt", value)
+}
+
+// Ge returns a filter that matches if the attribute is greater than or equal to the value.
+func Ge(attr string, value interface{}) Filter {
+	return compare(attr, "ge", value)
+}
+
+// Lt returns a filter that matches if the attribute is less than the value.
+func Lt(attr string, value interface{}) Filter {
+	return compare(attr, "lt", value)
+}
+
+// Le returns a filter that matches if the attribute is less than or equal to the value.
+func Le(attr string, value interface{}) Filter {
+	return compare(attr, "le", value)
+}
+
+// Pr returns a filter that matches if the attribute is present, i.e. it has a non-empty value.
+func Pr(attr string) Filter {
+	return Filter{expr: &attributeExpression{attr: attr, op: "pr"}}
+}
+
+// Not returns a filter that matches if f does not match. If f is empty, the empty filter is returned.
+func Not(f Filter) Filter {
+	if f.expr == nil {
+		return f
+	}
+
+	return Filter{expr: &notExpression{expr: f.expr}}
+}
+
+// ValuePath returns a filter that matches if at least one value of the multi-valued attribute matches f, for example
+// ValuePath("emails", Eq("type", "work")) renders as `emails[type eq "work"]`. The attributes of f are relative to the
+// multi-valued attribute.
+func ValuePath(attr string, f Filter) Filter {
+	if f.expr == nil {
+		return Pr(attr)
+	}
+
+	return Filter{expr: &valuePathExpression{attr: attr, expr: f.expr}}
+}
+
+func compare(attr string, op string, value interface{}) Filter {
+	return Filter{expr: &attributeExpression{attr: attr, op: op, value: value}}
+}
+
+// attributeExpression compares an attribute with a value, or checks if the attribute is present ("pr").
+type attributeExpression struct {
+	attr  string
+	op    string
+	value interface{}
+}
+
+func (e *attributeExpression) write(b *bytes.Buffer) {
+	b.WriteString(e.attr)
+	b.WriteByte(' ')
+	b.WriteString(e.op)
+
+	if e.op != "pr" {
+		b.WriteByte(' ')
+		b.WriteString(formatValue(e.value))
+	}
+}
+
+func (e *attributeExpression) precedence() int {
+	return precedenceUnary
+}
+
+// logicalExpression combines two expressions with "and" or "or".
+type logicalExpression struct {
+	op    string
+	p     int
+	left  expression
+	right expression
+}
+
+func (e *logicalExpression) write(b *bytes.Buffer) {
+	// Logical expressions are left-associative, so the right operand also needs parentheses if it has the same
+	// precedence. This keeps the structure of the filter when it is parsed again.
+	writeOperand(b, e.left, e.left.precedence() < e.p)
+	b.WriteByte(' ')
+	b.WriteString(e.op)
+	b.WriteByte(' ')
+	writeOperand(b, e.right, e.right.precedence() <= e.p)
+}
+
+func (e *logicalExpression) precedence() int {
+	return e.p
+}
+
+// notExpression negates an expression.
+type notExpression struct {
+	expr expression
+}
+
+func (e *notExpression) write(b *bytes.Buffer) {
+	b.WriteString("not ")
+	writeOperand(b, e.expr, true)
+}
+
+func (e *notExpression) precedence() int {
+	return precedenceUnary
+}
+
+// valuePathExpression filters the values of a multi-valued attribute.
+type valuePathExpression struct {
+	attr string
+	expr expression
+}
+
+func (e *valuePathExpression) write(b *bytes.Buffer) {
+	b.WriteString(e.attr)
+	b.WriteByte('[')
+	e.expr.write(b)
+	b.WriteByte(']')
+}
+
+func (e *valuePathExpression) precedence() int {
+	return precedenceUnary
+}
+
+func writeOperand(b *bytes.Buffer, e expression, parentheses bool) {
+	if !parentheses {
+		e.write(b)
+		return
+	}
+
+	b.WriteByte('(')
+	e.write(b)
+	b.WriteByte(')')
+}
+
+// formatValue returns the value as SCIM filter value. Strings, numbers, booleans, nil and time.Time values are
+// rendered as JSON. Other values, like structs or slices, are rendered as string using fmt.
+func formatValue(value interface{}) string {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(value)
+	if err != nil || b.Len() == 0 || b.Bytes()[0] == '{' || b.Bytes()[0] == '[' {
+		return formatValue(fmt.Sprint(value))
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Parse parses a SCIM filter expression, for example `userName eq "a@b.com" and active eq true`. Attribute names and
+// operators are case-insensitive. Strings are parsed as string values, numbers as json.Number values, true and false
+// as bool values and null as nil.
+//
+// If the filter is not valid, an error wrapping ErrInvalidFilter is returned. An empty string returns the empty
+// filter.
+func Parse(s string) (Filter, error) {
+	p := &parser{}
+
+	err := p.tokenize(s)
+	if err != nil {
+		return Filter{}, err
+	}
+
+	if len(p.tokens) == 0 {
+		return Filter{}, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return Filter{}, err
+	}
+
+	if t := p.peek(); t != nil {
+		return Filter{}, p.errorf(t, "unexpected %q", t.text)
+	}
+
+	return Filter{expr: expr}, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// is returns true if the token is a word equal to the given keyword, ignoring case.
+func (t *token) is(keyword string) bool {
+	return t != nil && t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+var punctuation = map[byte]tokenKind{
+	'(': tokenOpenParen,
+	')': tokenCloseParen,
+	'[': tokenOpenBracket,
+	']': tokenCloseBracket,
+}
+
+// tokenize splits s into tokens.
+func (p *parser) tokenize(s string) error {
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		if kind, ok := punctuation[c]; ok {
+			p.tokens = append(p.tokens, token{kind: kind, text: string(c), pos: i})
+			i++
+			continue
+		}
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			end, err := scanString(s, i)
+			if err != nil {
+				return err
+			}
+
+			p.tokens = append(p.tokens, token{kind: tokenString, text: s[i:end], pos: i})
+			i = end
+		default:
+			end := i
+			for end < len(s) && !strings.ContainsRune(" \t\n\r\"()[]", rune(s[end])) {
+				end++
+			}
+
+			p.tokens = append(p.tokens, token{kind: tokenWord, text: s[i:end], pos: i})
+			i = end
+		}
+	}
+
+	return nil
+}
+
+// scanString returns the end of the JSON string starting at the quote at position start.
+func scanString(s string, start int) (int, error) {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unterminated string at position %d", ErrInvalidFilter, start)
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+
+	return &p.tokens[p.pos]
+}
+
+func (p *parser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	t := p.next()
+	if t == nil {
+		return fmt.Errorf("%w: expected %q at end of filter", ErrInvalidFilter, text)
+	}
+
+	if t.kind != kind {
+		return p.errorf(t, "expected %q, got %q", text, t.text)
+	}
+
+	return nil
+}
+
+func (p *parser) errorf(t *token, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidFilter, fmt.Sprintf(format, args...), t.pos)
+}
+
+// parseOr parses: andExpr *("or" andExpr).
+func (p *parser) parseOr() (expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("or") {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalExpression{op: "or", p: precedenceOr, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses: unaryExpr *("and" unaryExpr).
+func (p *parser) parseAnd() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("and") {
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalExpression{op: "and", p: precedenceAnd, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses: "not" "(" filter ")" / "(" filter ")" / valuePath / attrExp.
+func (p *parser) parseUnary() (expression, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("%w: unexpected end of filter", ErrInvalidFilter)
+	}
+
+	if t.is("not") && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].kind == tokenOpenParen {
+		p.next()
+
+		expr, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notExpression{expr: expr}, nil
+	}
+
+	if t.kind == tokenOpenParen {
+		return p.parseGroup()
+	}
+
+	return p.parseAttribute()
+}
+
+// parseGroup parses: "(" filter ")".
+func (p *parser) parseGroup() (expression, error) {
+	err := p.expect(tokenOpenParen, "(")
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.expect(tokenCloseParen, ")")
+	if err != nil {
+		return nil, err
+	}
+
+	return expr, nil
+}
+
+// parseAttribute parses: attrPath "[" filter "]" / attrPath "pr" / attrPath compareOp compValue.
+func (p *parser) parseAttribute() (expression, error) {
+	t := p.next()
+	if t.kind != tokenWord || !isAttributePath(t.text) {
+		return nil, p.errorf(t, "expected attribute, got %q", t.text)
+	}
+
+	attr := t.text
+
+	if next := p.peek(); next != nil && next.kind == tokenOpenBracket {
+		p.next()
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expect(tokenCloseBracket, "]")
+		if err != nil {
+			return nil, err
+		}
+
+		return &valuePathExpression{attr: attr, expr: expr}, nil
+	}
+
+	op := p.next()
+	if op == nil {
+		return nil, fmt.Errorf("%w: expected operator after %q at end of filter", ErrInvalidFilter, attr)
+	}
+
+	if op.is("pr") {
+		return &attributeExpression{attr: attr, op: "pr"}, nil
+	}
+
+	if op.kind != tokenWord || !compareOperators[strings.ToLower(op.text)] {
+		return nil, p.errorf(op, "unknown operator %q", op.text)
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return &attributeExpression{attr: attr, op: strings.ToLower(op.text), value: value}, nil
+}
+
+var compareOperators = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true, "gt": true, "ge": true, "lt": true, "le": true,
+}
+
+// parseValue parses: false / null / true / number / string.
+func (p *parser) parseValue() (interface{}, error) {
+	t := p.next()
+	if t == nil {
+		return nil, fmt.Errorf("%w: expected value at end of filter", ErrInvalidFilter)
+	}
+
+	switch {
+	case t.kind == tokenString:
+		var s string
+		err := json.Unmarshal([]byte(t.text), &s)
+		if err != nil {
+			return nil, p.errorf(t, "invalid string %s", t.text)
+		}
+
+		return s, nil
+	case t.kind != tokenWord:
+		return nil, p.errorf(t, "expected value, got %q", t.text)
+	case t.is("true"):
+		return true, nil
+	case t.is("false"):
+		return false, nil
+	case t.is("null"):
+		return nil, nil
+	}
+
+	var n json.Number
+	err := json.Unmarshal([]byte(t.text), &n)
+	if err != nil {
+		return nil, p.errorf(t, "invalid value %q", t.text)
+	}
+
+	return n, nil
+}
+
+// isAttributePath returns true if s is a valid attribute path: [URI ":"] ATTRNAME *1("." ATTRNAME).
+func isAttributePath(s string) bool {
+	// The URI of the schema is everything up to the last colon, for example
+	// "urn:ietf:params:scim:schemas:core:2.0:User:userName".
+	if i := strings.LastIndexByte(s, ':'); i >= 0 {
+		s = s[i+1:]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 2 {
+		return false
+	}
+
+	for _, name := range parts {
+		if !isAttributeName(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isAttributeName returns true if s is a valid attribute name: ALPHA *("-" / "_" / DIGIT / ALPHA).
+func isAttributeName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+
+		switch {
+		case unicode.IsLetter(r):
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '_'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
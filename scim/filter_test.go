@@ -0,0 +1,170 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFilter_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		want   string
+	}{
+		{"empty", Filter{}, ""},
+		{"eq-string", Eq("userName", "a@b.com"), `userName eq "a@b.com"`},
+		{"eq-bool", Eq("active", true), `active eq true`},
+		{"eq-null", Eq("title", nil), `title eq null`},
+		{"gt-number", Gt("meta.version", 42), `meta.version gt 42`},
+		{"ge-time", Ge("meta.lastModified", time.Date(2011, 5, 13, 4, 42, 34, 0, time.UTC)), `meta.lastModified ge "2011-05-13T04:42:34Z"`},
+		{"escape-quote", Eq("name.familyName", `O"Malley`), `name.familyName eq "O\"Malley"`},
+		{"escape-backslash", Co("displayName", `a\b`), `displayName co "a\\b"`},
+		{"no-html-escape", Sw("title", "R&D <lab>"), `title sw "R&D <lab>"`},
+		{"struct-value", Eq("title", struct{ A int }{1}), `title eq "{1}"`},
+		{"pr", Pr("title"), `title pr`},
+		{"and", Eq("userName", "a@b.com").And(Eq("active", true)), `userName eq "a@b.com" and active eq true`},
+		{"and-empty", Filter{}.And(Pr("title")).And(Filter{}), `title pr`},
+		{
+			"and-or",
+			Eq("userType", "Employee").And(Co("emails", "example.com").Or(Co("emails.value", "example.org"))),
+			`userType eq "Employee" and (emails co "example.com" or emails.value co "example.org")`,
+		},
+		{
+			"or-and",
+			Pr("title").Or(Eq("a", 1).And(Eq("b", 2))),
+			`title pr or a eq 1 and b eq 2`,
+		},
+		{
+			"right-associative",
+			Pr("a").And(Pr("b").And(Pr("c"))),
+			`a pr and (b pr and c pr)`,
+		},
+		{"not", Ne("userType", "Employee").And(Not(Co("emails", "example.com"))), `userType ne "Employee" and not (emails co "example.com")`},
+		{
+			"value-path",
+			ValuePath("emails", Eq("type", "work").And(Co("value", "@example.com"))),
+			`emails[type eq "work" and value co "@example.com"]`,
+		},
+		{"value-path-empty", ValuePath("emails", Filter{}), `emails pr`},
+		{"all-operators", Ew("a", "x").And(Lt("b", 1.5)).And(Le("c", -1)), `a ew "x" and b lt 1.5 and c le -1`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParse_RFC7644 parses the filter examples of RFC 7644, section 3.4.2.2 and checks that the filters render to the
+// same string again.
+func TestParse_RFC7644(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{`userName eq "bjensen"`, ``},
+		{`name.familyName co "O'Malley"`, ``},
+		{`userName sw "J"`, ``},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "J"`, ``},
+		{`title pr`, ``},
+		{`meta.lastModified gt "2011-05-13T04:42:34Z"`, ``},
+		{`meta.lastModified ge "2011-05-13T04:42:34Z"`, ``},
+		{`meta.lastModified lt "2011-05-13T04:42:34Z"`, ``},
+		{`meta.lastModified le "2011-05-13T04:42:34Z"`, ``},
+		{`title pr and userType eq "Employee"`, ``},
+		{`title pr or userType eq "Intern"`, ``},
+		{`schemas eq "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"`, ``},
+		{`userType eq "Employee" and (emails co "example.com" or emails.value co "example.org")`, ``},
+		{`userType ne "Employee" and not (emails co "example.com" or emails.value co "example.org")`, ``},
+		{`userType eq "Employee" and (emails.type eq "work")`, `userType eq "Employee" and emails.type eq "work"`},
+		{`userType eq "Employee" and emails[type eq "work" and value co "@example.com"]`, ``},
+		{`emails[type eq "work" and value co "@example.com"] or ims[type eq "xmpp" and value co "@foo.com"]`, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			want := tt.want
+			if want == "" {
+				want = tt.filter
+			}
+
+			f, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := f.String(); got != want {
+				t.Errorf("Parse().String() = %v, want %v", got, want)
+			}
+
+			again, err := Parse(f.String())
+			if err != nil {
+				t.Fatalf("Parse() of rendered filter error = %v", err)
+			}
+			if !reflect.DeepEqual(again, f) {
+				t.Errorf("Parse() of rendered filter = %#v, want %#v", again, f)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   Filter
+	}{
+		{"empty", "  ", Filter{}},
+		{"case-insensitive", `userName EQ "a" AND active Eq TRUE`, Eq("userName", "a").And(Eq("active", true))},
+		{"number", `meta.version gt 1.5e3`, Gt("meta.version", json.Number("1.5e3"))},
+		{"null", `title eq null`, Eq("title", nil)},
+		{"escaped-string", `displayName eq "a\"b\\cé"`, Eq("displayName", "a\"b\\cé")},
+		{"precedence", `a pr or b pr and c pr`, Pr("a").Or(Pr("b").And(Pr("c")))},
+		{"left-associative", `a pr and b pr and c pr`, Pr("a").And(Pr("b")).And(Pr("c"))},
+		{"not-without-space", `not(a pr)`, Not(Pr("a"))},
+		{"attribute-named-not", `not eq "x"`, Eq("not", "x")},
+		{"builder", `userName eq "a@b.com" and active eq true`, Eq("userName", "a@b.com").And(Eq("active", true))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []string{
+		`userName`,
+		`userName eq`,
+		`userName xx "a"`,
+		`userName eq "a`,
+		`userName eq a`,
+		`userName eq "a" and`,
+		`userName eq "a" or or`,
+		`(userName eq "a"`,
+		`userName eq "a")`,
+		`emails[type eq "work"`,
+		`not (userName pr`,
+		`1abc pr`,
+		`name.given.name pr`,
+		`"userName" eq "a"`,
+		`userName eq ("a")`,
+	}
+	for _, filter := range tests {
+		t.Run(filter, func(t *testing.T) {
+			_, err := Parse(filter)
+			if !errors.Is(err, ErrInvalidFilter) {
+				t.Errorf("Parse() error = %v, want %v", err, ErrInvalidFilter)
+			}
+		})
+	}
+}
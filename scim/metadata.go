@@ -0,0 +1,215 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ServiceProviderConfig describes the SCIM features supported by Bonus.ly.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7643#section-5
+type ServiceProviderConfig struct {
+	// DocumentationUri is the URI of the documentation of the SCIM API.
+	DocumentationUri string `json:"documentationUri"`
+	// Patch describes the support of PATCH requests.
+	Patch Supported `json:"patch"`
+	// Bulk describes the support of bulk operations.
+	Bulk BulkConfig `json:"bulk"`
+	// Filter describes the support of filters.
+	Filter FilterConfig `json:"filter"`
+	// ChangePassword describes the support of password changes.
+	ChangePassword Supported `json:"changePassword"`
+	// Sort describes the support of sorting.
+	Sort Supported `json:"sort"`
+	// Etag describes the support of ETags.
+	Etag Supported `json:"etag"`
+	// AuthenticationSchemes are the supported authentication schemes.
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes"`
+}
+
+// Supported describes if a SCIM feature is supported.
+type Supported struct {
+	// Supported is true if the feature is supported.
+	Supported bool `json:"supported"`
+}
+
+// BulkConfig describes the support of SCIM bulk operations.
+type BulkConfig struct {
+	// Supported is true if bulk operations are supported.
+	Supported bool `json:"supported"`
+	// MaxOperations is the maximum number of operations of a bulk request.
+	MaxOperations int `json:"maxOperations"`
+	// MaxPayloadSize is the maximum size of a bulk request in bytes.
+	MaxPayloadSize int `json:"maxPayloadSize"`
+}
+
+// FilterConfig describes the support of SCIM filters.
+type FilterConfig struct {
+	// Supported is true if filters are supported.
+	Supported bool `json:"supported"`
+	// MaxResults is the maximum number of resources returned by a list request.
+	MaxResults int `json:"maxResults"`
+}
+
+// AuthenticationScheme describes an authentication scheme supported by the SCIM API.
+type AuthenticationScheme struct {
+	// Type of the authentication scheme, for example "oauthbearertoken".
+	Type string `json:"type"`
+	// Name of the authentication scheme.
+	Name string `json:"name"`
+	// Description of the authentication scheme.
+	Description string `json:"description"`
+	// SpecUri is the URI of the specification of the authentication scheme.
+	SpecUri string `json:"specUri"`
+	// DocumentationUri is the URI of the documentation of the authentication scheme.
+	DocumentationUri string `json:"documentationUri"`
+	// Primary is true if the authentication scheme is the preferred one.
+	Primary bool `json:"primary"`
+}
+
+// GetServiceProviderConfigOutput represents the output of the "Get metadata about the Bonusly SCIM API" operation.
+type GetServiceProviderConfigOutput struct {
+	// ServiceProviderConfig describes the supported SCIM features.
+	ServiceProviderConfig ServiceProviderConfig
+}
+
+// GetServiceProviderConfig returns the SCIM features supported by Bonus.ly.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-4
+func (c *Client) GetServiceProviderConfig(ctx context.Context) (*GetServiceProviderConfigOutput, error) {
+	var r ServiceProviderConfig
+	err := c.send(ctx, "get service provider config", http.MethodGet, "/ServiceProviderConfig", nil, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetServiceProviderConfigOutput{ServiceProviderConfig: r}, nil
+}
+
+// ResourceType describes a SCIM resource type, for example "User".
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7643#section-6
+type ResourceType struct {
+	// Id of the resource type.
+	Id string `json:"id"`
+	// Name of the resource type.
+	Name string `json:"name"`
+	// Description of the resource type.
+	Description string `json:"description"`
+	// Endpoint is the path of the resource type, relative to the endpoint, for example "/Users".
+	Endpoint string `json:"endpoint"`
+	// Schema is the URI of the core schema of the resource type.
+	Schema string `json:"schema"`
+	// SchemaExtensions are the schema extensions of the resource type.
+	SchemaExtensions []SchemaExtension `json:"schemaExtensions"`
+}
+
+// SchemaExtension is a schema extension of a ResourceType.
+type SchemaExtension struct {
+	// Schema is the URI of the schema extension.
+	Schema string `json:"schema"`
+	// Required is true if resources must contain the schema extension.
+	Required bool `json:"required"`
+}
+
+// ListResourceTypesOutput represents the output of the "List the SCIM resource types supported by Bonusly" operation.
+type ListResourceTypesOutput struct {
+	// ResourceTypes is a slice of all supported resource types.
+	ResourceTypes []ResourceType
+}
+
+// ListResourceTypes returns the SCIM resource types supported by Bonus.ly.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-4
+func (c *Client) ListResourceTypes(ctx context.Context) (*ListResourceTypesOutput, error) {
+	resourceTypes := make([]ResourceType, 0)
+
+	err := c.list(ctx, "list resource types", "/ResourceTypes", &resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResourceTypesOutput{ResourceTypes: resourceTypes}, nil
+}
+
+// Schema describes a SCIM schema.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7643#section-7
+type Schema struct {
+	// Id is the URI of the schema.
+	Id string `json:"id"`
+	// Name of the schema.
+	Name string `json:"name"`
+	// Description of the schema.
+	Description string `json:"description"`
+	// Attributes of the schema.
+	Attributes []Attribute `json:"attributes"`
+}
+
+// Attribute describes an attribute of a SCIM schema.
+type Attribute struct {
+	// Name of the attribute.
+	Name string `json:"name"`
+	// Type of the attribute, for example "string" or "complex".
+	Type string `json:"type"`
+	// MultiValued is true if the attribute has multiple values.
+	MultiValued bool `json:"multiValued"`
+	// Description of the attribute.
+	Description string `json:"description"`
+	// Required is true if the attribute must be set.
+	Required bool `json:"required"`
+	// CaseExact is true if the values of the attribute are case-sensitive.
+	CaseExact bool `json:"caseExact"`
+	// Mutability defines if and when the attribute can be changed, for example "readWrite" or "readOnly".
+	Mutability string `json:"mutability"`
+	// Returned defines when the attribute is returned, for example "default" or "never".
+	Returned string `json:"returned"`
+	// Uniqueness defines how unique the values of the attribute are, for example "none" or "server".
+	Uniqueness string `json:"uniqueness"`
+	// CanonicalValues are the values of the attribute defined by the service provider, for example "work".
+	CanonicalValues []string `json:"canonicalValues"`
+	// SubAttributes are the attributes of a complex attribute.
+	SubAttributes []Attribute `json:"subAttributes"`
+}
+
+// ListSchemasOutput represents the output of the "List the SCIM schemas supported by Bonusly" operation.
+type ListSchemasOutput struct {
+	// Schemas is a slice of all supported schemas.
+	Schemas []Schema
+}
+
+// ListSchemas returns the SCIM schemas supported by Bonus.ly.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-4
+func (c *Client) ListSchemas(ctx context.Context) (*ListSchemasOutput, error) {
+	schemas := make([]Schema, 0)
+
+	err := c.list(ctx, "list schemas", "/Schemas", &schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListSchemasOutput{Schemas: schemas}, nil
+}
+
+// list sends a GET request to the path and decodes the resources of the list response into v.
+func (c *Client) list(ctx context.Context, op string, path string, v interface{}) error {
+	var r listResponse
+	err := c.send(ctx, op, http.MethodGet, path, nil, &r)
+	if err != nil {
+		return err
+	}
+
+	if len(r.Resources) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(r.Resources, v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
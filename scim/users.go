@@ -0,0 +1,485 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	bonusly "github.com/groundfoghub/bonusly-sdk-go"
+)
+
+const (
+	// SchemaUser is the schema URI of the SCIM core user resource.
+	SchemaUser = "urn:ietf:params:scim:schemas:core:2.0:User"
+	// SchemaEnterpriseUser is the schema URI of the SCIM enterprise user extension.
+	SchemaEnterpriseUser = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	// SchemaListResponse is the schema URI of SCIM list responses.
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	// SchemaPatchOp is the schema URI of SCIM patch requests.
+	SchemaPatchOp = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+var (
+	ErrMissingUserId = errors.New("missing user id")
+)
+
+// User represents a SCIM user.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7643#section-4.1
+type User struct {
+	// Schemas are the schema URIs of the user. If empty, the schemas are set automatically when the user is created or
+	// replaced.
+	Schemas []string `json:"schemas,omitempty"`
+	// Id of the user, assigned by Bonus.ly.
+	Id string `json:"id,omitempty"`
+	// ExternalId is the id of the user in the identity provider.
+	ExternalId string `json:"externalId,omitempty"`
+	// UserName is the unique username of the user. Bonus.ly uses the email of the user as the username.
+	UserName string `json:"userName"`
+	// Name of the user.
+	Name *Name `json:"name,omitempty"`
+	// DisplayName is the name of the user as displayed to other users.
+	DisplayName string `json:"displayName,omitempty"`
+	// Title is the job title of the user.
+	Title string `json:"title,omitempty"`
+	// Emails of the user.
+	Emails []Email `json:"emails,omitempty"`
+	// Active defines if the user is active. Inactive users can not log in, give or receive bonuses. If nil, the
+	// attribute is not sent and Bonus.ly creates the user as active. Use Bool to set it.
+	Active *bool `json:"active,omitempty"`
+	// EnterpriseUser contains the attributes of the enterprise user extension.
+	EnterpriseUser *EnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	// Meta contains the metadata of the user, assigned by Bonus.ly.
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+// Name is the name of a SCIM user.
+type Name struct {
+	// Formatted is the full name of the user.
+	Formatted string `json:"formatted,omitempty"`
+	// GivenName is the first name of the user.
+	GivenName string `json:"givenName,omitempty"`
+	// FamilyName is the last name of the user.
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// Email is an email of a SCIM user.
+type Email struct {
+	// Value is the email address.
+	Value string `json:"value"`
+	// Type of the email, for example "work".
+	Type string `json:"type,omitempty"`
+	// Primary defines if the email is the primary email of the user.
+	Primary bool `json:"primary,omitempty"`
+}
+
+// EnterpriseUser contains the attributes of the SCIM enterprise user extension.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7643#section-4.3
+type EnterpriseUser struct {
+	// EmployeeNumber is the id of the user in the HR system.
+	EmployeeNumber string `json:"employeeNumber,omitempty"`
+	// Department of the user.
+	Department string `json:"department,omitempty"`
+	// Manager of the user.
+	Manager *Manager `json:"manager,omitempty"`
+}
+
+// Manager is the manager of a SCIM enterprise user.
+type Manager struct {
+	// Value is the id of the manager.
+	Value string `json:"value,omitempty"`
+	// DisplayName of the manager.
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Meta contains the metadata of a SCIM resource.
+type Meta struct {
+	// ResourceType is the name of the resource type, for example "User".
+	ResourceType string `json:"resourceType,omitempty"`
+	// Created is the time the resource was created.
+	Created time.Time `json:"created,omitempty"`
+	// LastModified is the time the resource was last modified.
+	LastModified time.Time `json:"lastModified,omitempty"`
+	// Location is the URI of the resource.
+	Location string `json:"location,omitempty"`
+	// Version is the version (ETag) of the resource.
+	Version string `json:"version,omitempty"`
+}
+
+// listResponse is a SCIM list response. The resources are decoded separately, based on the type of the resources.
+type listResponse struct {
+	TotalResults int             `json:"totalResults"`
+	ItemsPerPage int             `json:"itemsPerPage"`
+	StartIndex   int             `json:"startIndex"`
+	Resources    json.RawMessage `json:"Resources"`
+}
+
+// ListUsersInput represents the input of the "List users" operation.
+type ListUsersInput struct {
+	// Filter limits the result to users matching the filter, for example Eq("userName", "a@b.com") (optional). To use
+	// a filter string, parse it with Parse.
+	Filter Filter
+	// StartIndex is the 1-based index of the first user to return, used for pagination (optional).
+	StartIndex int
+	// Count is the maximum number of users to return (optional).
+	Count int
+}
+
+// ListUsersOutput represents the output of the "List users" operation.
+type ListUsersOutput struct {
+	// Users is a slice of all found users. If no users are found the slice will be empty.
+	Users []User
+	// TotalResults is the total number of users matching the filter, across all pages.
+	TotalResults int
+	// StartIndex is the 1-based index of the first user of the page.
+	StartIndex int
+	// ItemsPerPage is the number of users of the page.
+	ItemsPerPage int
+}
+
+// ListUsersPaginatorClient is the client interface required by the ListUsersPaginator.
+type ListUsersPaginatorClient interface {
+	ListUsers(context.Context, *ListUsersInput) (*ListUsersOutput, error)
+}
+
+// ListUsersPaginator is a paginator for the "List users" operation.
+type ListUsersPaginator struct {
+	client       ListUsersPaginatorClient
+	params       *ListUsersInput
+	firstPage    bool
+	offset       int
+	totalResults int
+}
+
+// NewListUsersPaginator returns a new ListUsersPaginator. If params is nil, all users are listed.
+func NewListUsersPaginator(client ListUsersPaginatorClient, params *ListUsersInput) *ListUsersPaginator {
+	if params == nil {
+		params = &ListUsersInput{}
+	}
+
+	offset := params.StartIndex
+	if offset < 1 {
+		offset = 1
+	}
+
+	return &ListUsersPaginator{
+		client:    client,
+		params:    params,
+		firstPage: true,
+		offset:    offset,
+	}
+}
+
+// HasMorePages returns true if more pages can be retrieved.
+func (p *ListUsersPaginator) HasMorePages() bool {
+	return p.firstPage || p.offset <= p.totalResults
+}
+
+// NextPage retrieves the next page of users.
+func (p *ListUsersPaginator) NextPage(ctx context.Context) (*ListUsersOutput, error) {
+	p.firstPage = false
+	p.params.StartIndex = p.offset
+
+	output, err := p.client.ListUsers(ctx, p.params)
+	if err != nil {
+		return nil, err
+	}
+
+	p.offset += len(output.Users)
+	p.totalResults = output.TotalResults
+
+	// Stop if the page is empty, even if the total number of results claims otherwise, to never loop forever.
+	if len(output.Users) == 0 {
+		p.totalResults = 0
+	}
+
+	return output, nil
+}
+
+// ListUsers returns a list of users.
+//
+// The params parameter can be nil, which will cause the operation to use the default parameters for the operation.
+// To retrieve all users use the ListUsersPaginator.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2
+func (c *Client) ListUsers(ctx context.Context, params *ListUsersInput) (*ListUsersOutput, error) {
+	if params == nil {
+		params = &ListUsersInput{}
+	}
+
+	u, err := newListUsersURL(c.endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var r listResponse
+	err = decodeResponse("list users", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]User, 0)
+	if len(r.Resources) > 0 {
+		err = json.Unmarshal(r.Resources, &users)
+		if err != nil {
+			return nil, fmt.Errorf("list users: %w", err)
+		}
+	}
+
+	return &ListUsersOutput{
+		Users:        users,
+		TotalResults: r.TotalResults,
+		StartIndex:   r.StartIndex,
+		ItemsPerPage: r.ItemsPerPage,
+	}, nil
+}
+
+// newListUsersURL returns the URL to get a list of users (ListUsers) based on the provided endpoint and params. If
+// the URL can not be created a non-nil error is returned and the URL is nil.
+func newListUsersURL(endpoint Endpoint, params *ListUsersInput) (*url.URL, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/Users", endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+
+	if !params.Filter.IsZero() {
+		q.Add("filter", params.Filter.String())
+	}
+
+	if params.StartIndex > 0 {
+		q.Add("startIndex", strconv.Itoa(params.StartIndex))
+	}
+
+	if params.Count > 0 {
+		q.Add("count", strconv.Itoa(params.Count))
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u, nil
+}
+
+// GetUserInput represents the input of the "Retrieve a user" operation.
+type GetUserInput struct {
+	// Id of the user to retrieve.
+	Id string
+}
+
+// GetUserOutput represents the output of the "Retrieve a user" operation.
+type GetUserOutput struct {
+	// User is the retrieved user.
+	User User
+}
+
+// GetUser returns a single user.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.1
+func (c *Client) GetUser(ctx context.Context, params *GetUserInput) (*GetUserOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingUserId
+	}
+
+	var r User
+	err := c.send(ctx, "get user", http.MethodGet, userPath(params.Id), nil, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetUserOutput{User: r}, nil
+}
+
+// CreateUserInput represents the input of the "Create a user" operation.
+type CreateUserInput struct {
+	// User to create. The Id and Meta of the user are ignored.
+	User User
+}
+
+// CreateUserOutput represents the output of the "Create a user" operation.
+type CreateUserOutput struct {
+	// User is the created user.
+	User User
+}
+
+// CreateUser creates a new user.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.3
+func (c *Client) CreateUser(ctx context.Context, params *CreateUserInput) (*CreateUserOutput, error) {
+	if params == nil {
+		return nil, bonusly.ErrMissingParams
+	}
+
+	var r User
+	err := c.send(ctx, "create user", http.MethodPost, "/Users", newUserBody(params.User), &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateUserOutput{User: r}, nil
+}
+
+// ReplaceUserInput represents the input of the "Update an existing user" operation, replacing all attributes.
+type ReplaceUserInput struct {
+	// Id of the user to replace.
+	Id string
+	// User contains the new attributes of the user. Attributes that are not set are cleared.
+	User User
+}
+
+// ReplaceUserOutput represents the output of the "Update an existing user" operation, replacing all attributes.
+type ReplaceUserOutput struct {
+	// User is the replaced user.
+	User User
+}
+
+// ReplaceUser replaces all attributes of a user. To update single attributes use PatchUser.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.5.1
+func (c *Client) ReplaceUser(ctx context.Context, params *ReplaceUserInput) (*ReplaceUserOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingUserId
+	}
+
+	var r User
+	err := c.send(ctx, "replace user", http.MethodPut, userPath(params.Id), newUserBody(params.User), &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplaceUserOutput{User: r}, nil
+}
+
+// PatchOp is the kind of a PatchOperation.
+type PatchOp string
+
+const (
+	PatchOpAdd     PatchOp = "add"
+	PatchOpRemove  PatchOp = "remove"
+	PatchOpReplace PatchOp = "replace"
+)
+
+// PatchOperation is a single operation of a PatchUser request.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.5.2
+type PatchOperation struct {
+	// Op is the kind of the operation.
+	Op PatchOp `json:"op"`
+	// Path is the attribute path the operation applies to, for example "active" or "name.givenName" (optional for
+	// add and replace operations).
+	Path string `json:"path,omitempty"`
+	// Value is the new value of the attribute. It is ignored for remove operations.
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PatchUserInput represents the input of the "Update an existing user" operation, updating single attributes.
+type PatchUserInput struct {
+	// Id of the user to update.
+	Id string
+	// Operations are applied to the user in order.
+	Operations []PatchOperation
+}
+
+// PatchUserOutput represents the output of the "Update an existing user" operation, updating single attributes.
+type PatchUserOutput struct {
+	// User is the updated user.
+	User User
+}
+
+// PatchUser updates single attributes of a user.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.5.2
+func (c *Client) PatchUser(ctx context.Context, params *PatchUserInput) (*PatchUserOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingUserId
+	}
+
+	body := struct {
+		Schemas    []string         `json:"schemas"`
+		Operations []PatchOperation `json:"Operations"`
+	}{
+		Schemas:    []string{SchemaPatchOp},
+		Operations: params.Operations,
+	}
+
+	var r User
+	err := c.send(ctx, "patch user", http.MethodPatch, userPath(params.Id), body, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PatchUserOutput{User: r}, nil
+}
+
+// SetUserActiveInput represents the input of the "Activate or deactivate a user" operation.
+type SetUserActiveInput struct {
+	// Id of the user to activate or deactivate.
+	Id string
+	// Active defines if the user is activated (true) or deactivated (false).
+	Active bool
+}
+
+// SetUserActiveOutput represents the output of the "Activate or deactivate a user" operation.
+type SetUserActiveOutput struct {
+	// User is the activated or deactivated user.
+	User User
+}
+
+// SetUserActive activates or deactivates a user.
+func (c *Client) SetUserActive(ctx context.Context, params *SetUserActiveInput) (*SetUserActiveOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingUserId
+	}
+
+	out, err := c.PatchUser(ctx, &PatchUserInput{
+		Id:         params.Id,
+		Operations: []PatchOperation{{Op: PatchOpReplace, Path: "active", Value: params.Active}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetUserActiveOutput{User: out.User}, nil
+}
+
+// Bool returns a pointer to the value, for example to set User.Active.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// userPath returns the path of the user with the given id, relative to the endpoint.
+func userPath(id string) string {
+	return fmt.Sprintf("/Users/%s", url.PathEscape(id))
+}
+
+// newUserBody returns the user as sent in create and replace requests. The id and metadata are removed, and the
+// schemas are set if they are missing.
+func newUserBody(u User) User {
+	u.Id = ""
+	u.Meta = nil
+
+	if len(u.Schemas) == 0 {
+		u.Schemas = []string{SchemaUser}
+		if u.EnterpriseUser != nil {
+			u.Schemas = append(u.Schemas, SchemaEnterpriseUser)
+		}
+	}
+
+	return u
+}
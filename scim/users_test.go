@@ -0,0 +1,211 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	bonusly "github.com/groundfoghub/bonusly-sdk-go"
+)
+
+func mustURL(t *testing.T, s string) *url.URL {
+	t.Helper()
+
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatalf("invalid url: %v", err)
+	}
+
+	return u
+}
+
+func Test_newListUsersURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *ListUsersInput
+		want   *url.URL
+	}{
+		{"no-settings", &ListUsersInput{}, mustURL(t, fmt.Sprintf("%s/Users", EndpointProduction))},
+		{
+			"filter",
+			&ListUsersInput{Filter: Eq("userName", "a@b.com")},
+			mustURL(t, fmt.Sprintf("%s/Users?filter=%s", EndpointProduction, url.QueryEscape(`userName eq "a@b.com"`))),
+		},
+		{
+			"pagination",
+			&ListUsersInput{StartIndex: 11, Count: 10},
+			mustURL(t, fmt.Sprintf("%s/Users?count=10&startIndex=11", EndpointProduction)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newListUsersURL(EndpointProduction, tt.params)
+			if err != nil {
+				t.Fatalf("newListUsersURL() error = %v", err)
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("newListUsersURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type mockListUsersClient struct {
+	total int
+	calls []int
+}
+
+func (m *mockListUsersClient) ListUsers(_ context.Context, params *ListUsersInput) (*ListUsersOutput, error) {
+	m.calls = append(m.calls, params.StartIndex)
+
+	n := m.total - params.StartIndex + 1
+	if n > params.Count {
+		n = params.Count
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	return &ListUsersOutput{Users: make([]User, n), TotalResults: m.total, StartIndex: params.StartIndex}, nil
+}
+
+func TestListUsersPaginator(t *testing.T) {
+	client := &mockListUsersClient{total: 25}
+
+	var users []User
+
+	paginator := NewListUsersPaginator(client, &ListUsersInput{Count: 10})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		users = append(users, output.Users...)
+	}
+
+	if len(users) != 25 {
+		t.Errorf("ListUsersPaginator(), got = %d, want = %d", len(users), 25)
+	}
+	if want := []int{1, 11, 21}; !reflect.DeepEqual(client.calls, want) {
+		t.Errorf("ListUsersPaginator() start indexes = %v, want %v", client.calls, want)
+	}
+}
+
+func TestClient_Users(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		body   string
+	}
+
+	var got []request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test" || r.Header.Get("Accept") != MediaType {
+			t.Errorf("request headers = %v", r.Header)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if len(body) > 0 && r.Header.Get("Content-Type") != MediaType {
+			t.Errorf("Content-Type = %v, want %v", r.Header.Get("Content-Type"), MediaType)
+		}
+
+		got = append(got, request{method: r.Method, path: r.URL.RequestURI(), body: string(body)})
+
+		w.Header().Set("Content-Type", MediaType)
+
+		if r.Method == http.MethodGet && r.URL.Path == "/Users" {
+			_, _ = w.Write([]byte(`{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:ListResponse"],
+				"totalResults": 1,
+				"itemsPerPage": 1,
+				"startIndex": 1,
+				"Resources": [{"id": "1", "userName": "frodo@example.com", "active": true}]
+			}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+			"id": "1",
+			"userName": "frodo@example.com",
+			"active": false,
+			"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Ring Bearers"},
+			"meta": {"resourceType": "User", "location": "https://bonus.ly/api/scim/v2/Users/1"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(bonusly.Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+	ctx := context.TODO()
+
+	list, err := client.ListUsers(ctx, &ListUsersInput{Filter: Eq("userName", "frodo@example.com")})
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if list.TotalResults != 1 || len(list.Users) != 1 || list.Users[0].UserName != "frodo@example.com" || list.Users[0].Active == nil || !*list.Users[0].Active {
+		t.Errorf("ListUsers() got = %+v", list)
+	}
+
+	user, err := client.GetUser(ctx, &GetUserInput{Id: "1"})
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.User.EnterpriseUser == nil || user.User.EnterpriseUser.Department != "Ring Bearers" || user.User.Meta == nil {
+		t.Errorf("GetUser() got = %+v", user.User)
+	}
+
+	_, err = client.CreateUser(ctx, &CreateUserInput{User: User{
+		UserName:       "frodo@example.com",
+		Active:         Bool(true),
+		Name:           &Name{GivenName: "Frodo", FamilyName: "Baggins"},
+		EnterpriseUser: &EnterpriseUser{Department: "Ring Bearers"},
+	}})
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	_, err = client.CreateUser(ctx, &CreateUserInput{User: User{UserName: "sam@example.com"}})
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	_, err = client.ReplaceUser(ctx, &ReplaceUserInput{Id: "1", User: User{Id: "ignored", UserName: "frodo@example.com"}})
+	if err != nil {
+		t.Fatalf("ReplaceUser() error = %v", err)
+	}
+
+	_, err = client.SetUserActive(ctx, &SetUserActiveInput{Id: "1", Active: false})
+	if err != nil {
+		t.Fatalf("SetUserActive() error = %v", err)
+	}
+
+	want := []request{
+		{http.MethodGet, "/Users?filter=userName+eq+%22frodo%40example.com%22", ""},
+		{http.MethodGet, "/Users/1", ""},
+		{http.MethodPost, "/Users", `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User","urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"],"userName":"frodo@example.com","name":{"givenName":"Frodo","familyName":"Baggins"},"active":true,"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User":{"department":"Ring Bearers"}}`},
+		{http.MethodPost, "/Users", `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"sam@example.com"}`},
+		{http.MethodPut, "/Users/1", `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"frodo@example.com"}`},
+		{http.MethodPatch, "/Users/1", `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"active","value":false}]}`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("requests got = %+v,\nwant %+v", got, want)
+	}
+
+	_, err = client.GetUser(ctx, &GetUserInput{})
+	if !errors.Is(err, ErrMissingUserId) {
+		t.Errorf("GetUser() error = %v, want %v", err, ErrMissingUserId)
+	}
+
+	_, err = client.CreateUser(ctx, nil)
+	if !errors.Is(err, bonusly.ErrMissingParams) {
+		t.Errorf("CreateUser() error = %v, want %v", err, bonusly.ErrMissingParams)
+	}
+}
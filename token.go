@@ -0,0 +1,109 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrMissingToken is returned by a TokenProvider if no token is available.
+	ErrMissingToken = errors.New("missing token")
+)
+
+// TokenProvider provides the token used to authenticate requests with the Bonus.ly REST API.
+//
+// The Client asks the TokenProvider for the token on every request, so a TokenProvider can rotate tokens without the
+// need to create a new Client. Implementations must be safe for concurrent use.
+type TokenProvider interface {
+	// Token returns the current token.
+	Token(ctx context.Context) (string, error)
+}
+
+// WithTokenProvider sets the TokenProvider used by the bonusly.Client. It replaces the token of the
+// bonusly.Configuration.
+func WithTokenProvider(provider TokenProvider) ClientOption {
+	return func(c *Client) {
+		c.tokenProvider = provider
+	}
+}
+
+// StaticToken is a TokenProvider that always returns the same token.
+type StaticToken string
+
+// Token returns the static token. An empty token is returned without an error, so a Client created without a token
+// still sends its requests, which the Bonus.ly REST API rejects with ErrUnauthorized.
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// EnvToken is a TokenProvider that reads the token from the environment variable with the given name on every
+// request.
+type EnvToken string
+
+// Token returns the value of the environment variable. If the environment variable is not set or empty,
+// ErrMissingToken is returned.
+func (e EnvToken) Token(context.Context) (string, error) {
+	token := strings.TrimSpace(os.Getenv(string(e)))
+	if token == "" {
+		return "", fmt.Errorf("%w: environment variable %s is empty", ErrMissingToken, string(e))
+	}
+
+	return token, nil
+}
+
+// FileToken is a TokenProvider that reads the token from a file. The file is read again whenever its modification
+// time or size changes, which makes it a good fit for secrets that are mounted into a container and rotated while the
+// application is running.
+//
+// Use NewFileToken to create a new FileToken.
+type FileToken struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileToken returns a new FileToken that reads the token from the file at path. Leading and trailing whitespace of
+// the file content is ignored.
+func NewFileToken(path string) *FileToken {
+	return &FileToken{path: path}
+}
+
+// Token returns the token stored in the file. If the file is empty, ErrMissingToken is returned.
+func (f *FileToken) Token(context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	b, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("%w: file %s is empty", ErrMissingToken, f.path)
+	}
+
+	f.token = token
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+
+	return f.token, nil
+}
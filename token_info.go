@@ -0,0 +1,88 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+)
+
+// TokenInfo describes the token used by the Client.
+type TokenInfo struct {
+	// AccessLevel of the token. The access level is a best-effort guess, because the Bonus.ly REST API does not report
+	// it. It is APIKeyAccessLevelUnknown if the access level could not be determined, which is common for owners with
+	// API keys of different scopes.
+	AccessLevel APIKeyAccessLevel
+	// User is the owner of the token.
+	User ExtendedUser
+	// Company is the company the token belongs to.
+	Company Company
+}
+
+// TokenInfo returns information about the token used by the Client: its access level (read, write or admin) and the
+// user and company it belongs to. The user and company are exact, the access level is best-effort only.
+//
+// The Bonus.ly REST API does not expose the access level of the token used for a request. TokenInfo derives it from
+// the scopes of the API keys of the token owner (ListAPIKeys) and only sends read requests:
+//   - If all API keys of the owner have the same scope, the token must have that scope.
+//   - If the owner has admin keys and keys with another scope, a request for financial user data is sent. If the API
+//     denies the request, the token is not an admin token and the admin scope is ruled out. A successful response is
+//     not treated as proof of admin access, because the API might ignore the parameter for other tokens.
+//
+// If the access level can still not be narrowed down to a single scope, or the API keys can not be listed, the access
+// level is APIKeyAccessLevelUnknown. Callers that must know whether writes succeed should treat an unknown access
+// level like read-only access.
+func (c *Client) TokenInfo(ctx context.Context) (*TokenInfo, error) {
+	me, err := c.Me(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := c.GetCompany(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := c.accessLevel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenInfo{AccessLevel: level, User: me.User, Company: company.Company}, nil
+}
+
+// accessLevel determines the access level of the token from the scopes of the API keys of the token owner.
+func (c *Client) accessLevel(ctx context.Context) (APIKeyAccessLevel, error) {
+	keys, err := c.ListAPIKeys(ctx)
+	if isAccessDenied(err) {
+		return APIKeyAccessLevelUnknown, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	scopes := make(map[APIKeyAccessLevel]bool)
+	for _, key := range keys.APIKeys {
+		scopes[key.Scope] = true
+	}
+
+	if len(scopes) > 1 && scopes[APIKeyAccessLevelAdmin] {
+		_, err = c.ListUsers(ctx, &ListUsersInput{Limit: 1, ShowFinancialData: true})
+		if isAccessDenied(err) {
+			delete(scopes, APIKeyAccessLevelAdmin)
+		} else if err != nil {
+			return "", err
+		}
+	}
+
+	if len(scopes) == 1 {
+		for scope := range scopes {
+			return scope, nil
+		}
+	}
+
+	return APIKeyAccessLevelUnknown, nil
+}
+
+// isAccessDenied returns true if the error is an *APIError caused by missing permissions.
+func isAccessDenied(err error) bool {
+	return errors.Is(err, ErrForbidden) || errors.Is(err, ErrUnauthorized)
+}
@@ -0,0 +1,69 @@
+package bonusly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_TokenInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		keysStatus  int
+		keys        string
+		adminStatus int
+		want        APIKeyAccessLevel
+		wantErr     bool
+	}{
+		{"single-scope", http.StatusOK, `[{"id": "k1", "scope": "write"}, {"id": "k2", "scope": "write"}]`, 0, APIKeyAccessLevelWrite, false},
+		{"admin-ruled-out", http.StatusOK, `[{"id": "k1", "scope": "read"}, {"id": "k2", "scope": "admin"}]`, http.StatusForbidden, APIKeyAccessLevelRead, false},
+		{"admin-not-proven-unknown", http.StatusOK, `[{"id": "k1", "scope": "read"}, {"id": "k2", "scope": "admin"}]`, http.StatusOK, APIKeyAccessLevelUnknown, false},
+		{"mixed-scopes-unknown", http.StatusOK, `[{"id": "k1", "scope": "read"}, {"id": "k2", "scope": "write"}]`, 0, APIKeyAccessLevelUnknown, false},
+		{"no-keys-unknown", http.StatusOK, `[]`, 0, APIKeyAccessLevelUnknown, false},
+		{"keys-forbidden-unknown", http.StatusForbidden, `[]`, 0, APIKeyAccessLevelUnknown, false},
+		{"error", http.StatusInternalServerError, `[]`, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected request %v %v", r.Method, r.URL)
+				}
+
+				switch {
+				case r.URL.Path == "/users/me":
+					_, _ = w.Write([]byte(`{"success": true, "result": {"id": "1", "email": "frodo@example.com", "giving_balance": 50}}`))
+				case r.URL.Path == "/companies/show":
+					_, _ = w.Write([]byte(`{"success": true, "result": {"id": "c1", "name": "Example Corp"}}`))
+				case r.URL.Path == "/api_keys":
+					w.WriteHeader(tt.keysStatus)
+					_, _ = w.Write([]byte(`{"success": true, "result": ` + tt.keys + `}`))
+				case r.URL.Path == "/users" && r.URL.Query().Get("show_financial_data") == "true" && tt.adminStatus != 0:
+					w.WriteHeader(tt.adminStatus)
+					_, _ = w.Write([]byte(`{"success": true, "result": []}`))
+				default:
+					t.Errorf("unexpected request %v %v", r.Method, r.URL)
+				}
+			}))
+			defer server.Close()
+
+			client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+			got, err := client.TokenInfo(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TokenInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.AccessLevel != tt.want {
+				t.Errorf("TokenInfo() AccessLevel = %v, want %v", got.AccessLevel, tt.want)
+			}
+			if got.User.Email != "frodo@example.com" || got.User.GiveBalance != 50 || got.Company.Name != "Example Corp" {
+				t.Errorf("TokenInfo() got = %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStaticToken(t *testing.T) {
+	got, err := StaticToken("secret").Token(context.TODO())
+	if err != nil || got != "secret" {
+		t.Errorf("Token() = %v, %v, want %v", got, err, "secret")
+	}
+
+	got, err = StaticToken("").Token(context.TODO())
+	if err != nil || got != "" {
+		t.Errorf("Token() = %v, %v, want empty token", got, err)
+	}
+}
+
+func TestEnvToken(t *testing.T) {
+	const name = "BONUSLY_SDK_GO_TEST_TOKEN"
+	defer os.Unsetenv(name)
+
+	_ = os.Setenv(name, " secret\n")
+
+	got, err := EnvToken(name).Token(context.TODO())
+	if err != nil || got != "secret" {
+		t.Errorf("Token() = %v, %v, want %v", got, err, "secret")
+	}
+
+	_ = os.Unsetenv(name)
+
+	_, err = EnvToken(name).Token(context.TODO())
+	if !errors.Is(err, ErrMissingToken) {
+		t.Errorf("Token() error = %v, want %v", err, ErrMissingToken)
+	}
+}
+
+func TestFileToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bonusly")
+	if err != nil {
+		t.Fatalf("TempDir() error = %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	writeToken(t, path, "first\n", time.Now().Add(-time.Minute))
+
+	provider := NewFileToken(path)
+
+	got, err := provider.Token(context.TODO())
+	if err != nil || got != "first" {
+		t.Errorf("Token() = %v, %v, want %v", got, err, "first")
+	}
+
+	writeToken(t, path, "second", time.Now())
+
+	got, err = provider.Token(context.TODO())
+	if err != nil || got != "second" {
+		t.Errorf("Token() after rotation = %v, %v, want %v", got, err, "second")
+	}
+
+	_, err = NewFileToken(filepath.Join(dir, "missing")).Token(context.TODO())
+	if err == nil {
+		t.Errorf("Token() error = %v, want error", err)
+	}
+}
+
+func TestClient_DoTokenProvider(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	token := StaticToken("first")
+	provider := tokenProviderFunc(func() StaticToken { return token })
+	client := New(Configuration{Token: "ignored"}, WithTokenProvider(provider))
+
+	for _, want := range []StaticToken{"first", "second"} {
+		token = want
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatalf("NewRequest() error = %v", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		drainAndCloseBody(resp)
+
+		if got != "Bearer "+string(want) {
+			t.Errorf("Do() Authorization = %v, want %v", got, "Bearer "+string(want))
+		}
+	}
+}
+
+func TestClient_DoTokenPerAttempt(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		if len(got) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	tokens := []StaticToken{"expired", "rotated"}
+	provider := tokenProviderFunc(func() StaticToken {
+		token := tokens[0]
+		tokens = tokens[1:]
+		return token
+	})
+
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+	client := New(Configuration{}, WithTokenProvider(provider), WithRetryPolicy(policy))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	drainAndCloseBody(resp)
+
+	if len(got) != 2 || got[0] != "Bearer expired" || got[1] != "Bearer rotated" {
+		t.Errorf("Do() Authorization = %v, want [Bearer expired Bearer rotated]", got)
+	}
+}
+
+func TestClient_DoEmptyToken(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header["Authorization"]
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := New(Configuration{}).Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	drainAndCloseBody(resp)
+
+	// The server trims the trailing space of the header value.
+	if len(got) != 1 || got[0] != "Bearer" {
+		t.Errorf("Do() Authorization = %q, want %q", got, "Bearer")
+	}
+}
+
+type tokenProviderFunc func() StaticToken
+
+func (f tokenProviderFunc) Token(ctx context.Context) (string, error) {
+	return f().Token(ctx)
+}
+
+func writeToken(t *testing.T, path string, token string, modTime time.Time) {
+	t.Helper()
+
+	err := ioutil.WriteFile(path, []byte(token), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	err = os.Chtimes(path, modTime, modTime)
+	if err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+}
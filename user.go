@@ -1,6 +1,7 @@
 package bonusly
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -239,11 +240,6 @@ func (c *Client) ListUsers(ctx context.Context, params *ListUsersInput) (*ListUs
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
-	if err != nil {
-		return nil, err
-	}
-
 	type response struct {
 		baseAPIResponse
 
@@ -251,15 +247,11 @@ func (c *Client) ListUsers(ctx context.Context, params *ListUsersInput) (*ListUs
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("list users", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("list users: %v", r.Message)
-	}
-
 	return &ListUsersOutput{Users: r.Result}, nil
 }
 
@@ -339,6 +331,42 @@ type ExtendedUser struct {
 	LifeTimeEarningsWithCurrency string `json:"lifetime_earnings_with_currency"`
 }
 
+// UnmarshalJSON is a custom json.Unmarshaler for the ExtendedUser type. Without it, the promoted
+// BaseUser.UnmarshalJSON would be used and the balances of the ExtendedUser would never be set.
+func (u *ExtendedUser) UnmarshalJSON(data []byte) error {
+	var base BaseUser
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	balances := struct {
+		EarningBalance               int    `json:"earning_balance"`
+		EarningBalanceWithCurrency   string `json:"earning_balance_with_currency"`
+		GiveBalance                  int    `json:"giving_balance"`
+		GiveBalanceWithCurrency      string `json:"giving_balance_with_currency"`
+		LifeTimeEarnings             int    `json:"lifetime_earnings"`
+		LifeTimeEarningsWithCurrency string `json:"lifetime_earnings_with_currency"`
+	}{}
+
+	err = json.Unmarshal(data, &balances)
+	if err != nil {
+		return err
+	}
+
+	*u = ExtendedUser{
+		BaseUser:                     base,
+		EarningBalance:               balances.EarningBalance,
+		EarningBalanceWithCurrency:   balances.EarningBalanceWithCurrency,
+		GiveBalance:                  balances.GiveBalance,
+		GiveBalanceWithCurrency:      balances.GiveBalanceWithCurrency,
+		LifeTimeEarnings:             balances.LifeTimeEarnings,
+		LifeTimeEarningsWithCurrency: balances.LifeTimeEarningsWithCurrency,
+	}
+
+	return nil
+}
+
 func (c *Client) GetUser(ctx context.Context, params *GetUserInput) (*GetUserOutput, error) {
 	if params == nil {
 		return nil, ErrMissingUserId
@@ -355,7 +383,38 @@ func (c *Client) GetUser(ctx context.Context, params *GetUserInput) (*GetUserOut
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
+	type response struct {
+		baseAPIResponse
+
+		User ExtendedUser `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("get user", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetUserOutput{User: r.User}, nil
+}
+
+// MeOutput represents the output of the "Me" operation.
+type MeOutput struct {
+	// User is the owner of the token.
+	User ExtendedUser
+}
+
+// Me returns the user the token belongs to.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/me
+func (c *Client) Me(ctx context.Context) (*MeOutput, error) {
+	u := fmt.Sprintf("%s/users/me", c.endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -367,14 +426,359 @@ func (c *Client) GetUser(ctx context.Context, params *GetUserInput) (*GetUserOut
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("me", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("get user: %v", r.Message)
+	return &MeOutput{User: r.User}, nil
+}
+
+// AutocompleteUsersInput represents the input of the "Autocomplete" operation.
+type AutocompleteUsersInput struct {
+	// Search is the prefix of the name, username or email of the users to find.
+	Search string
+	// Limit is the maximum number of users to return (optional).
+	Limit int
+}
+
+// AutocompleteUsersOutput represents the output of the "Autocomplete" operation.
+type AutocompleteUsersOutput struct {
+	// Users is a slice of all users matching the search. If no users are found the slice will be empty.
+	Users []BaseUser
+}
+
+// AutocompleteUsers returns the users whose name, username or email starts with the search prefix. It is much faster
+// than listing all users and meant to resolve partial names, for example while a user is typing.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/autocomplete
+func (c *Client) AutocompleteUsers(ctx context.Context, params *AutocompleteUsersInput) (*AutocompleteUsersOutput, error) {
+	if params == nil {
+		return nil, ErrMissingParams
 	}
 
-	return &GetUserOutput{User: r.User}, nil
+	u, err := newAutocompleteUsersURL(c.endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		Result []BaseUser `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse("autocomplete users", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AutocompleteUsersOutput{Users: r.Result}, nil
+}
+
+// newAutocompleteUsersURL returns the URL to autocomplete users (AutocompleteUsers) based on the provided endpoint and
+// params. If the URL can not be created a non-nil error is returned and the URL is nil.
+func newAutocompleteUsersURL(endpoint Endpoint, params *AutocompleteUsersInput) (*url.URL, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/users/autocomplete", endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+
+	q.Add("search", params.Search)
+
+	if params.Limit > 0 {
+		q.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u, nil
+}
+
+// CreateUserInput represents the input of the "Create a User" operation.
+type CreateUserInput struct {
+	// Email of the user.
+	Email string
+	// FirstName of the user.
+	FirstName string
+	// LastName of the user.
+	LastName string
+	// UserMode of the user (optional). If not set, the user is a normal user.
+	UserMode UserMode
+	// ManagerEmail is the email of the manager of the user (optional).
+	ManagerEmail string
+	// HiredOn is the date the user was hired (optional). Only the date is sent, the time is ignored.
+	HiredOn time.Time
+	// BudgetBoost is the number of points added to the monthly allowance of the user (optional).
+	BudgetBoost int
+	// ExternalUniqueId is the id of the user in an external system, for example the HR system (optional).
+	ExternalUniqueId string
+	// Country of the user, for example "US" (optional).
+	Country string
+	// TimeZone of the user, for example "America/New_York" (optional).
+	TimeZone string
+	// CustomProperties of the user, mapping the name of the custom property to its value, for example
+	// "department" to "marketing" (optional).
+	CustomProperties map[string]string
+}
+
+// CreateUserOutput represents the output of the "Create a User" operation.
+type CreateUserOutput struct {
+	// User is the created user.
+	User ExtendedUser
+}
+
+type createUserBody struct {
+	Email            string            `json:"email"`
+	FirstName        string            `json:"first_name,omitempty"`
+	LastName         string            `json:"last_name,omitempty"`
+	UserMode         UserMode          `json:"user_mode,omitempty"`
+	ManagerEmail     string            `json:"manager_email,omitempty"`
+	HiredOn          string            `json:"hired_on,omitempty"`
+	BudgetBoost      int               `json:"budget_boost,omitempty"`
+	ExternalUniqueId string            `json:"external_unique_id,omitempty"`
+	Country          string            `json:"country,omitempty"`
+	TimeZone         string            `json:"time_zone,omitempty"`
+	CustomProperties map[string]string `json:"custom_properties,omitempty"`
+}
+
+// CreateUser creates a new user. This operation requires an admin token.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/create-a-user
+func (c *Client) CreateUser(ctx context.Context, params *CreateUserInput) (*CreateUserOutput, error) {
+	if params == nil {
+		return nil, ErrMissingParams
+	}
+
+	body := createUserBody{
+		Email:            params.Email,
+		FirstName:        params.FirstName,
+		LastName:         params.LastName,
+		UserMode:         params.UserMode,
+		ManagerEmail:     params.ManagerEmail,
+		BudgetBoost:      params.BudgetBoost,
+		ExternalUniqueId: params.ExternalUniqueId,
+		Country:          params.Country,
+		TimeZone:         params.TimeZone,
+		CustomProperties: params.CustomProperties,
+	}
+
+	if !params.HiredOn.IsZero() {
+		body.HiredOn = params.HiredOn.Format("2006-01-02")
+	}
+
+	u := fmt.Sprintf("%s/users", c.endpoint)
+	user, err := c.sendUser(ctx, "create user", http.MethodPost, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateUserOutput{User: *user}, nil
+}
+
+// UpdateUserInput represents the input of the "Update a User" operation.
+//
+// Only fields that are set (non-nil) are updated.
+type UpdateUserInput struct {
+	// Id of the user to update.
+	Id string
+	// Email of the user (optional).
+	Email *string
+	// FirstName of the user (optional).
+	FirstName *string
+	// LastName of the user (optional).
+	LastName *string
+	// UserMode of the user (optional).
+	UserMode *UserMode
+	// ManagerEmail is the email of the manager of the user (optional). Set it to an empty string to remove the
+	// manager.
+	ManagerEmail *string
+	// HiredOn is the date the user was hired (optional). Only the date is sent, the time is ignored.
+	HiredOn *time.Time
+	// BudgetBoost is the number of points added to the monthly allowance of the user (optional).
+	BudgetBoost *int
+	// ExternalUniqueId is the id of the user in an external system, for example the HR system (optional).
+	ExternalUniqueId *string
+	// Country of the user, for example "US" (optional).
+	Country *string
+	// TimeZone of the user, for example "America/New_York" (optional).
+	TimeZone *string
+	// CustomProperties of the user, mapping the name of the custom property to its value (optional). Only the
+	// custom properties contained in the map are updated.
+	CustomProperties map[string]string
+}
+
+// UpdateUserOutput represents the output of the "Update a User" operation.
+type UpdateUserOutput struct {
+	// User is the updated user.
+	User ExtendedUser
+}
+
+type updateUserBody struct {
+	Email            *string           `json:"email,omitempty"`
+	FirstName        *string           `json:"first_name,omitempty"`
+	LastName         *string           `json:"last_name,omitempty"`
+	UserMode         *UserMode         `json:"user_mode,omitempty"`
+	ManagerEmail     *string           `json:"manager_email,omitempty"`
+	HiredOn          *string           `json:"hired_on,omitempty"`
+	BudgetBoost      *int              `json:"budget_boost,omitempty"`
+	ExternalUniqueId *string           `json:"external_unique_id,omitempty"`
+	Country          *string           `json:"country,omitempty"`
+	TimeZone         *string           `json:"time_zone,omitempty"`
+	CustomProperties map[string]string `json:"custom_properties,omitempty"`
+	Status           *string           `json:"status,omitempty"`
+}
+
+// UpdateUser updates a user. This operation requires an admin token.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/update-a-user
+func (c *Client) UpdateUser(ctx context.Context, params *UpdateUserInput) (*UpdateUserOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingUserId
+	}
+
+	body := updateUserBody{
+		Email:            params.Email,
+		FirstName:        params.FirstName,
+		LastName:         params.LastName,
+		UserMode:         params.UserMode,
+		ManagerEmail:     params.ManagerEmail,
+		BudgetBoost:      params.BudgetBoost,
+		ExternalUniqueId: params.ExternalUniqueId,
+		Country:          params.Country,
+		TimeZone:         params.TimeZone,
+		CustomProperties: params.CustomProperties,
+	}
+
+	if params.HiredOn != nil {
+		hiredOn := params.HiredOn.Format("2006-01-02")
+		body.HiredOn = &hiredOn
+	}
+
+	u := fmt.Sprintf("%s/users/%s", c.endpoint, params.Id)
+	user, err := c.sendUser(ctx, "update user", http.MethodPut, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateUserOutput{User: *user}, nil
+}
+
+// DeactivateUserInput represents the input of the "Deactivate a User" operation.
+type DeactivateUserInput struct {
+	// Id of the user to deactivate.
+	Id string
+}
+
+// DeactivateUserOutput represents the output of the "Deactivate a User" operation.
+type DeactivateUserOutput struct {
+	// Id of the deactivated user.
+	Id string
+}
+
+// DeactivateUser deactivates (archives) a user. A deactivated user can no longer log in, give or receive bonuses, but
+// the bonuses of the user are kept. This operation requires an admin token.
+//
+// See: https://bonusly.docs.apiary.io/#reference/0/users/deactivate-a-user
+func (c *Client) DeactivateUser(ctx context.Context, params *DeactivateUserInput) (*DeactivateUserOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingUserId
+	}
+
+	u := fmt.Sprintf("%s/users/%s", c.endpoint, params.Id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var r baseAPIResponse
+	err = decodeResponse("deactivate user", resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeactivateUserOutput{Id: params.Id}, nil
+}
+
+// ReactivateUserInput represents the input of the "Reactivate a User" operation.
+type ReactivateUserInput struct {
+	// Id of the user to reactivate.
+	Id string
+}
+
+// ReactivateUserOutput represents the output of the "Reactivate a User" operation.
+type ReactivateUserOutput struct {
+	// User is the reactivated user.
+	User ExtendedUser
+}
+
+// ReactivateUser reactivates a user that was deactivated with DeactivateUser. This operation requires an admin token.
+//
+// Note: The Bonus.ly API has no dedicated endpoint to reactivate a user. Instead, the status of the user is set to
+// "active" using the "Update a User" endpoint.
+func (c *Client) ReactivateUser(ctx context.Context, params *ReactivateUserInput) (*ReactivateUserOutput, error) {
+	if params == nil || params.Id == "" {
+		return nil, ErrMissingUserId
+	}
+
+	status := "active"
+	body := updateUserBody{Status: &status}
+
+	u := fmt.Sprintf("%s/users/%s", c.endpoint, params.Id)
+	user, err := c.sendUser(ctx, "reactivate user", http.MethodPut, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReactivateUserOutput{User: *user}, nil
+}
+
+// sendUser sends the body as JSON to the given URL and returns the user of the response.
+func (c *Client) sendUser(ctx context.Context, op string, method string, u string, body interface{}) (*ExtendedUser, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		baseAPIResponse
+
+		User ExtendedUser `json:"result"`
+	}
+
+	var r response
+	err = decodeResponse(op, resp, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.User, nil
 }
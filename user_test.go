@@ -3,8 +3,13 @@ package bonusly
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -330,3 +335,153 @@ func Test_newListUsersURL(t *testing.T) {
 		})
 	}
 }
+
+func TestExtendedUser_Unmarshal(t *testing.T) {
+	data := []byte(`{"id": "1", "email": "frodo@example.com", "hired_on": "2022-03-01", "earning_balance": 120, "giving_balance": 50, "lifetime_earnings": 900}`)
+
+	var got ExtendedUser
+	err := json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+
+	if got.Email != "frodo@example.com" || got.HiredOn != time.Date(2022, 03, 01, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("UnmarshalJSON() got = %+v", got.BaseUser)
+	}
+	if got.EarningBalance != 120 || got.GiveBalance != 50 || got.LifeTimeEarnings != 900 {
+		t.Errorf("UnmarshalJSON() got = %+v", got)
+	}
+}
+
+func Test_newAutocompleteUsersURL(t *testing.T) {
+	type args struct {
+		params *AutocompleteUsersInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *url.URL
+		wantErr bool
+	}{
+		{
+			"search",
+			args{params: &AutocompleteUsersInput{Search: "jo"}},
+			mustURL(t, fmt.Sprintf("%s/users/autocomplete?search=jo", EndpointProduction)),
+			false,
+		},
+		{
+			"search-escaped",
+			args{params: &AutocompleteUsersInput{Search: "jo doe"}},
+			mustURL(t, fmt.Sprintf("%s/users/autocomplete?search=%s", EndpointProduction, url.QueryEscape("jo doe"))),
+			false,
+		},
+		{
+			"limit",
+			args{params: &AutocompleteUsersInput{Search: "jo", Limit: 5}},
+			mustURL(t, fmt.Sprintf("%s/users/autocomplete?limit=5&search=jo", EndpointProduction)),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newAutocompleteUsersURL(EndpointProduction, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newAutocompleteUsersURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("newAutocompleteUsersURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_AutocompleteUsersMissingParams(t *testing.T) {
+	client := New(Configuration{Token: "test"})
+
+	if _, err := client.AutocompleteUsers(context.TODO(), nil); !errors.Is(err, ErrMissingParams) {
+		t.Errorf("AutocompleteUsers() error = %v, want %v", err, ErrMissingParams)
+	}
+}
+
+func TestClient_UserLifecycle(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		body   string
+	}
+
+	var got []request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		got = append(got, request{method: r.Method, path: r.URL.Path, body: string(body)})
+
+		if r.Method == http.MethodDelete {
+			_, _ = w.Write([]byte(`{"success": true}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": "1", "email": "frodo@example.com", "earning_balance": 10}}`))
+	}))
+	defer server.Close()
+
+	client := New(Configuration{Token: "test"}, WithEndpoint(Endpoint(server.URL)))
+
+	created, err := client.CreateUser(context.TODO(), &CreateUserInput{
+		Email:            "frodo@example.com",
+		FirstName:        "Frodo",
+		UserMode:         UserModeReceiver,
+		HiredOn:          time.Date(2021, 9, 22, 12, 0, 0, 0, time.UTC),
+		ExternalUniqueId: "E-1",
+		CustomProperties: map[string]string{"department": "ring bearers"},
+	})
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if created.User.Id != "1" || created.User.EarningBalance != 10 {
+		t.Errorf("CreateUser() got = %+v", created.User)
+	}
+
+	mode := UserModeNormal
+	manager := ""
+	boost := 0
+
+	_, err = client.UpdateUser(context.TODO(), &UpdateUserInput{Id: "1", UserMode: &mode, ManagerEmail: &manager, BudgetBoost: &boost})
+	if err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	deactivated, err := client.DeactivateUser(context.TODO(), &DeactivateUserInput{Id: "1"})
+	if err != nil {
+		t.Fatalf("DeactivateUser() error = %v", err)
+	}
+	if deactivated.Id != "1" {
+		t.Errorf("DeactivateUser() got = %v, want %v", deactivated.Id, "1")
+	}
+
+	_, err = client.ReactivateUser(context.TODO(), &ReactivateUserInput{Id: "1"})
+	if err != nil {
+		t.Fatalf("ReactivateUser() error = %v", err)
+	}
+
+	want := []request{
+		{http.MethodPost, "/users", `{"email":"frodo@example.com","first_name":"Frodo","user_mode":"receiver","hired_on":"2021-09-22","external_unique_id":"E-1","custom_properties":{"department":"ring bearers"}}`},
+		{http.MethodPut, "/users/1", `{"user_mode":"normal","manager_email":"","budget_boost":0}`},
+		{http.MethodDelete, "/users/1", ``},
+		{http.MethodPut, "/users/1", `{"status":"active"}`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("requests got = %+v, want %+v", got, want)
+	}
+
+	_, err = client.UpdateUser(context.TODO(), &UpdateUserInput{})
+	if !errors.Is(err, ErrMissingUserId) {
+		t.Errorf("UpdateUser() error = %v, want %v", err, ErrMissingUserId)
+	}
+
+	_, err = client.CreateUser(context.TODO(), nil)
+	if !errors.Is(err, ErrMissingParams) {
+		t.Errorf("CreateUser() error = %v, want %v", err, ErrMissingParams)
+	}
+}
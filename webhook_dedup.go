@@ -0,0 +1,661 @@
+package bonusly
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// EventStore remembers which webhook events were processed, so events redelivered by Bonus.ly are not processed
+// twice. Implementations must be safe for concurrent use.
+//
+// Before an event is processed, it is claimed with Claim. Claim must be atomic, so if several receivers share the
+// store, only one of them processes the event. After the event was processed, the claim is turned into a processed
+// event with Mark, and if processing failed, the claim is removed with Release.
+//
+// The SDK ships an in-memory store (NewMemoryEventStore) and a file-backed store (NewFileEventStore). To store the
+// events somewhere else, for example in Redis or a SQL database, implement the interface or use EventStoreFuncs. In
+// Redis, Claim can be implemented with SET NX and an expiry, in a SQL database with an INSERT into a table with a
+// unique key. Claims should expire, so the event of a receiver that crashed while processing it is not blocked
+// forever.
+type EventStore interface {
+	// Seen returns true if the event with the given key was marked as processed.
+	Seen(ctx context.Context, key string) (bool, error)
+	// Claim atomically claims the event with the given key for processing. It returns false if the event was marked as
+	// processed or is claimed already.
+	Claim(ctx context.Context, key string) (bool, error)
+	// Release removes the claim of the event with the given key, so it can be claimed again.
+	Release(ctx context.Context, key string) error
+	// Mark marks the event with the given key as processed and removes its claim.
+	Mark(ctx context.Context, key string) error
+}
+
+// EventStoreFuncs is an EventStore that calls the given functions.
+type EventStoreFuncs struct {
+	// SeenFunc is called by Seen.
+	SeenFunc func(ctx context.Context, key string) (bool, error)
+	// ClaimFunc is called by Claim.
+	ClaimFunc func(ctx context.Context, key string) (bool, error)
+	// ReleaseFunc is called by Release.
+	ReleaseFunc func(ctx context.Context, key string) error
+	// MarkFunc is called by Mark.
+	MarkFunc func(ctx context.Context, key string) error
+}
+
+// Seen calls SeenFunc.
+func (s EventStoreFuncs) Seen(ctx context.Context, key string) (bool, error) {
+	return s.SeenFunc(ctx, key)
+}
+
+// Claim calls ClaimFunc.
+func (s EventStoreFuncs) Claim(ctx context.Context, key string) (bool, error) {
+	return s.ClaimFunc(ctx, key)
+}
+
+// Release calls ReleaseFunc.
+func (s EventStoreFuncs) Release(ctx context.Context, key string) error {
+	return s.ReleaseFunc(ctx, key)
+}
+
+// Mark calls MarkFunc.
+func (s EventStoreFuncs) Mark(ctx context.Context, key string) error {
+	return s.MarkFunc(ctx, key)
+}
+
+// DeduplicateOption is a functional option to configure the middleware returned by DeduplicateWebhookEvents.
+type DeduplicateOption func(d *deduplicator)
+
+// WithEventKey sets the function that returns the key used to identify an event in the EventStore. If the function
+// returns an empty key, the event is not deduplicated.
+//
+// Default: DefaultWebhookEventKey
+func WithEventKey(fn func(event *WebhookEvent) string) DeduplicateOption {
+	return func(d *deduplicator) {
+		d.key = fn
+	}
+}
+
+// WithEventStoreErrorHandler sets the function that is called if an event could not be marked as processed, or its
+// claim could not be released. Since the response for the event was already written at this point, the error can not
+// be reported to Bonus.ly anymore.
+//
+// Default: errors are ignored
+func WithEventStoreErrorHandler(fn func(r *http.Request, err error)) DeduplicateOption {
+	return func(d *deduplicator) {
+		d.onMarkError = fn
+	}
+}
+
+// WithDeduplicateVerifier sets the WebhookVerifier used to verify that requests were sent by Bonus.ly before the
+// EventStore is used. Requests failing the verification are rejected with 401 (Unauthorized), so forged requests can
+// neither claim events nor find out which events were processed. Processed requests are recorded by the verifier.
+//
+// Without a verifier, the middleware must be wrapped by a handler that verifies the requests.
+//
+// Default: nil
+func WithDeduplicateVerifier(verifier *WebhookVerifier) DeduplicateOption {
+	return func(d *deduplicator) {
+		d.verifier = verifier
+	}
+}
+
+// DefaultWebhookEventKey returns the id of the event. If the event has no id, the type of the event and the id of the
+// resource of the event are used instead, for example "bonus.created:<bonus-id>".
+func DefaultWebhookEventKey(event *WebhookEvent) string {
+	if event.Id != "" {
+		return event.Id
+	}
+
+	var data struct {
+		Id string `json:"id"`
+	}
+
+	if json.Unmarshal(event.Data, &data) != nil || data.Id == "" {
+		return ""
+	}
+
+	return string(event.Type) + ":" + data.Id
+}
+
+// DeduplicateWebhookEvents returns a middleware for webhook receivers, like the WebhookHandler, that passes every
+// event only once to the next handler.
+//
+// An event is claimed in the store before the next handler is called. It is marked as processed only if the next
+// handler responded with a 2xx status code, otherwise the claim is released, so failed events are processed again when
+// Bonus.ly redelivers them. Events that were already processed are acknowledged with 200 (OK) without calling the next
+// handler. While an event is claimed, concurrent deliveries of the same event are rejected with 503 (Service
+// Unavailable), so Bonus.ly redelivers them later. If the store fails, the middleware responds with 500 (Internal
+// Server Error).
+//
+// The middleware looks up events before the next handler runs. If the next handler verifies the requests, for example
+// a WebhookHandler with a WebhookVerifier, unverified requests could claim events and learn which events were
+// processed. Set the verifier with WithDeduplicateVerifier, or wrap the middleware in a handler that verifies the
+// requests.
+//
+// Requests that are not POST requests or have a body that is not a webhook event are passed to the next handler
+// unchanged.
+func DeduplicateWebhookEvents(store EventStore, options ...DeduplicateOption) func(http.Handler) http.Handler {
+	d := &deduplicator{
+		store: store,
+		key:   DefaultWebhookEventKey,
+	}
+
+	for _, fn := range options {
+		fn(d)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d.serveHTTP(w, r, next)
+		})
+	}
+}
+
+type deduplicator struct {
+	store       EventStore
+	key         func(event *WebhookEvent) string
+	onMarkError func(r *http.Request, err error)
+	verifier    *WebhookVerifier
+}
+
+//nolint: cyclop
+func (d *deduplicator) serveHTTP(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if r.Method != http.MethodPost {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	if d.verifier != nil {
+		err := d.verifier.VerifyWebhookRequest(r)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	body, err := readAndRestoreBody(r)
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+
+	event, err := ParseWebhookEvent(body)
+	if err != nil {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	key := d.key(event)
+	if key == "" {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	claimed, err := d.store.Claim(r.Context(), key)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if !claimed {
+		d.rejectClaimed(w, r, key)
+		return
+	}
+
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	next.ServeHTTP(rec, r)
+
+	d.finish(r, key, rec.status >= 200 && rec.status <= 299)
+}
+
+// rejectClaimed responds to a delivery of an event that could not be claimed. If the event was processed, the delivery
+// is acknowledged. Otherwise, the event is being processed and Bonus.ly must deliver it again later.
+func (d *deduplicator) rejectClaimed(w http.ResponseWriter, r *http.Request, key string) {
+	seen, err := d.store.Seen(r.Context(), key)
+	switch {
+	case err != nil:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	case seen:
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "event is being processed", http.StatusServiceUnavailable)
+	}
+}
+
+// finish marks the claimed event with the given key as processed if it was processed successfully, otherwise the claim
+// is released.
+func (d *deduplicator) finish(r *http.Request, key string, ok bool) {
+	var err error
+	if ok {
+		err = d.store.Mark(r.Context(), key)
+		if err != nil {
+			// Release the claim, so the event is processed again instead of being blocked.
+			_ = d.store.Release(r.Context(), key)
+		} else if d.verifier != nil {
+			d.verifier.RecordWebhookRequest(r)
+		}
+	} else {
+		err = d.store.Release(r.Context(), key)
+	}
+
+	if err != nil && d.onMarkError != nil {
+		d.onMarkError(r, err)
+	}
+}
+
+// statusRecorder is a http.ResponseWriter that remembers the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+
+	return r.ResponseWriter.Write(b)
+}
+
+// MemoryEventStore is an EventStore that keeps the keys of processed events in memory. If the store is full, the
+// least recently used key is removed. Keys expire after the TTL of the store.
+//
+// Use NewMemoryEventStore to create a new MemoryEventStore.
+type MemoryEventStore struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// order contains the entries, sorted from the most to the least recently used.
+	order *list.List
+	items map[string]*list.Element
+	// claims contains the keys of the events that are currently processed.
+	claims map[string]struct{}
+}
+
+type memoryEventStoreEntry struct {
+	key     string
+	expires time.Time
+}
+
+// NewMemoryEventStore returns a new MemoryEventStore that keeps at most capacity keys for the duration of the ttl. A
+// capacity of 0 keeps an unlimited number of keys and a ttl of 0 keeps the keys forever.
+func NewMemoryEventStore(capacity int, ttl time.Duration) *MemoryEventStore {
+	return &MemoryEventStore{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+		claims:   make(map[string]struct{}),
+	}
+}
+
+// Seen returns true if the key was marked and has not expired or been removed yet.
+func (s *MemoryEventStore) Seen(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.seen(key), nil
+}
+
+// Claim claims the key, unless the key was marked or is claimed already.
+func (s *MemoryEventStore) Claim(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.claims[key]; exists || s.seen(key) {
+		return false, nil
+	}
+
+	s.claims[key] = struct{}{}
+
+	return true, nil
+}
+
+// Release removes the claim of the key.
+func (s *MemoryEventStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, key)
+
+	return nil
+}
+
+// Mark marks the key as processed.
+func (s *MemoryEventStore) Mark(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, key)
+
+	now := s.now()
+
+	entry := &memoryEventStoreEntry{key: key}
+	if s.ttl > 0 {
+		entry.expires = now.Add(s.ttl)
+	}
+
+	if e, exists := s.items[key]; exists {
+		e.Value = entry
+		s.order.MoveToFront(e)
+	} else {
+		s.items[key] = s.order.PushFront(entry)
+	}
+
+	for e := s.order.Back(); e != nil && s.capacity > 0 && s.order.Len() > s.capacity; e = s.order.Back() {
+		s.remove(e)
+	}
+
+	// Remove expired keys from the back, so keys that are never used again do not fill up the store.
+	for e := s.order.Back(); e != nil && s.expired(e.Value.(*memoryEventStoreEntry), now); e = s.order.Back() {
+		s.remove(e)
+	}
+
+	return nil
+}
+
+// Len returns the number of keys in the store, including expired keys that were not removed yet.
+func (s *MemoryEventStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
+
+// seen returns true if the key was marked and has not expired yet. The caller must hold s.mu.
+func (s *MemoryEventStore) seen(key string) bool {
+	e, exists := s.items[key]
+	if !exists {
+		return false
+	}
+
+	if s.expired(e.Value.(*memoryEventStoreEntry), s.now()) {
+		s.remove(e)
+		return false
+	}
+
+	s.order.MoveToFront(e)
+
+	return true
+}
+
+func (s *MemoryEventStore) expired(entry *memoryEventStoreEntry, now time.Time) bool {
+	return !entry.expires.IsZero() && !now.Before(entry.expires)
+}
+
+func (s *MemoryEventStore) remove(e *list.Element) {
+	s.order.Remove(e)
+	delete(s.items, e.Value.(*memoryEventStoreEntry).key)
+}
+
+// fileEventStoreCompactInterval is the number of marked keys after which a FileEventStore checks whether the file
+// needs to be compacted.
+const fileEventStoreCompactInterval = 1000
+
+// FileEventStore is an EventStore that keeps the keys of processed events in a file, so they survive a restart of the
+// application. Every marked key is appended to the file as a JSON line. Keys expire after the TTL of the store.
+// Expired keys are removed from the file when the store is opened, and while the store is used once at least half of
+// the lines of the file belong to expired keys. Claims are kept in memory only.
+//
+// The file must not be shared by multiple processes. Use NewFileEventStore to create a new FileEventStore.
+type FileEventStore struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+	// compactInterval is the number of marked keys after which the store checks whether the file needs compaction.
+	compactInterval int
+
+	mu     sync.Mutex
+	file   *os.File
+	keys   map[string]time.Time
+	claims map[string]struct{}
+	// lines is the number of lines in the file, including lines of expired keys and keys marked more than once.
+	lines int
+	// marks is the number of keys marked since the last compaction check.
+	marks int
+}
+
+type fileEventStoreEntry struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// NewFileEventStore opens the FileEventStore stored in the file at path, creating the file if it does not exist. A ttl
+// of 0 keeps the keys forever. The store must be closed with Close.
+func NewFileEventStore(path string, ttl time.Duration) (*FileEventStore, error) {
+	s := &FileEventStore{
+		path:            path,
+		ttl:             ttl,
+		now:             time.Now,
+		compactInterval: fileEventStoreCompactInterval,
+		keys:            make(map[string]time.Time),
+		claims:          make(map[string]struct{}),
+	}
+
+	err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// load reads the keys of the file and rewrites the file without the expired keys.
+func (s *FileEventStore) load() error {
+	f, err := os.Open(s.path) //nolint: gosec
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	now := s.now()
+
+	if f != nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var entry fileEventStoreEntry
+
+			// Lines that can not be decoded, for example a line that was only partially written, are skipped.
+			if json.Unmarshal(scanner.Bytes(), &entry) != nil || entry.Key == "" {
+				continue
+			}
+
+			if entry.Expires.IsZero() || now.Before(entry.Expires) {
+				s.keys[entry.Key] = entry.Expires
+			}
+		}
+
+		err = scanner.Err()
+		cerr := f.Close()
+		if err != nil {
+			return err
+		}
+		if cerr != nil {
+			return cerr
+		}
+	}
+
+	return s.rewrite()
+}
+
+// rewrite replaces the file with a file that only contains the current keys and opens it for appending. The
+// previously opened file is closed.
+//nolint: cyclop
+func (s *FileEventStore) rewrite() error {
+	tmp := s.path + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600) //nolint: gosec
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+
+	for key, expires := range s.keys {
+		err = enc.Encode(fileEventStoreEntry{Key: key, Expires: expires})
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	err = w.Flush()
+	if err == nil {
+		err = f.Sync()
+	}
+
+	cerr := f.Close()
+	if err != nil {
+		return err
+	}
+	if cerr != nil {
+		return cerr
+	}
+
+	err = os.Rename(tmp, s.path)
+	if err != nil {
+		return err
+	}
+
+	f, err = os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o600) //nolint: gosec
+	if err != nil {
+		return err
+	}
+
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+
+	s.file = f
+	s.lines = len(s.keys)
+
+	return nil
+}
+
+// compact removes the expired keys and rewrites the file if at least half of its lines are not needed anymore. It is
+// called every compactInterval marked keys.
+func (s *FileEventStore) compact() error {
+	s.marks = 0
+
+	now := s.now()
+	for key, expires := range s.keys {
+		if !expires.IsZero() && !now.Before(expires) {
+			delete(s.keys, key)
+		}
+	}
+
+	if s.lines < 2*len(s.keys) || s.lines == 0 {
+		return nil
+	}
+
+	return s.rewrite()
+}
+
+// Seen returns true if the key was marked and has not expired yet.
+func (s *FileEventStore) Seen(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.seen(key), nil
+}
+
+// Claim claims the key, unless the key was marked or is claimed already.
+func (s *FileEventStore) Claim(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.claims[key]; exists || s.seen(key) {
+		return false, nil
+	}
+
+	s.claims[key] = struct{}{}
+
+	return true, nil
+}
+
+// Release removes the claim of the key.
+func (s *FileEventStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, key)
+
+	return nil
+}
+
+// seen returns true if the key was marked and has not expired yet. The caller must hold s.mu.
+func (s *FileEventStore) seen(key string) bool {
+	expires, exists := s.keys[key]
+	if !exists {
+		return false
+	}
+
+	if !expires.IsZero() && !s.now().Before(expires) {
+		delete(s.keys, key)
+		return false
+	}
+
+	return true
+}
+
+// Mark marks the key as processed, removes its claim and appends it to the file.
+func (s *FileEventStore) Mark(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expires time.Time
+	if s.ttl > 0 {
+		expires = s.now().Add(s.ttl)
+	}
+
+	b, err := json.Marshal(fileEventStoreEntry{Key: key, Expires: expires})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.file.Write(append(b, '\n'))
+	if err != nil {
+		return err
+	}
+
+	err = s.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	s.keys[key] = expires
+	s.lines++
+	s.marks++
+	delete(s.claims, key)
+
+	if s.compactInterval > 0 && s.marks >= s.compactInterval {
+		return s.compact()
+	}
+
+	return nil
+}
+
+// Close closes the file of the store.
+func (s *FileEventStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
@@ -0,0 +1,448 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeduplicateWebhookEvents(t *testing.T) {
+	var processed []string
+	var markErrors int
+
+	handler := NewWebhookHandler(
+		OnBonusCreated(func(_ context.Context, event *BonusCreatedEvent) error {
+			if event.Bonus.Reason == "fail" {
+				return errors.New("callback failed")
+			}
+
+			processed = append(processed, event.Bonus.Id)
+			return nil
+		}),
+	)
+
+	store := NewMemoryEventStore(100, time.Hour)
+	middleware := DeduplicateWebhookEvents(store, WithEventStoreErrorHandler(func(_ *http.Request, _ error) {
+		markErrors++
+	}))(handler)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"first", `{"id": "e1", "type": "bonus.created", "data": {"id": "b1"}}`, http.StatusOK},
+		{"redelivered", `{"id": "e1", "type": "bonus.created", "data": {"id": "b1"}}`, http.StatusOK},
+		{"failed", `{"id": "e2", "type": "bonus.created", "data": {"id": "b2", "reason": "fail"}}`, http.StatusInternalServerError},
+		{"failed-redelivered", `{"id": "e2", "type": "bonus.created", "data": {"id": "b2"}}`, http.StatusOK},
+		{"without-event-id", `{"type": "bonus.created", "data": {"id": "b3"}}`, http.StatusOK},
+		{"without-event-id-redelivered", `{"type": "bonus.created", "data": {"id": "b3"}}`, http.StatusOK},
+		{"invalid", `{`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			middleware.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/bonusly", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.want {
+				t.Errorf("ServeHTTP() status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+
+	if strings.Join(processed, ",") != "b1,b2,b3" {
+		t.Errorf("processed = %v, want [b1 b2 b3]", processed)
+	}
+
+	if store.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", store.Len())
+	}
+
+	if markErrors != 0 {
+		t.Errorf("mark errors = %d, want 0", markErrors)
+	}
+}
+
+func TestDeduplicateWebhookEvents_Store(t *testing.T) {
+	body := `{"id": "e1", "type": "bonus.created", "data": {"id": "b1"}}`
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("claim-error", func(t *testing.T) {
+		store := EventStoreFuncs{
+			ClaimFunc: func(_ context.Context, _ string) (bool, error) { return false, errors.New("unavailable") },
+		}
+
+		rec := httptest.NewRecorder()
+		DeduplicateWebhookEvents(store)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("mark-error", func(t *testing.T) {
+		var released bool
+		store := EventStoreFuncs{
+			ClaimFunc:   func(_ context.Context, _ string) (bool, error) { return true, nil },
+			ReleaseFunc: func(_ context.Context, _ string) error { released = true; return nil },
+			MarkFunc:    func(_ context.Context, _ string) error { return errors.New("unavailable") },
+		}
+
+		var got error
+		middleware := DeduplicateWebhookEvents(store, WithEventStoreErrorHandler(func(_ *http.Request, err error) {
+			got = err
+		}))
+
+		rec := httptest.NewRecorder()
+		middleware(next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+		if rec.Code != http.StatusOK || got == nil || !released {
+			t.Errorf("ServeHTTP() status = %d, error = %v, released = %t, want %d, an error and released", rec.Code, got,
+				released, http.StatusOK)
+		}
+	})
+
+	t.Run("release-error", func(t *testing.T) {
+		store := EventStoreFuncs{
+			ClaimFunc:   func(_ context.Context, _ string) (bool, error) { return true, nil },
+			ReleaseFunc: func(_ context.Context, _ string) error { return errors.New("unavailable") },
+		}
+
+		var got error
+		middleware := DeduplicateWebhookEvents(store, WithEventStoreErrorHandler(func(_ *http.Request, err error) {
+			got = err
+		}))
+
+		failing := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		rec := httptest.NewRecorder()
+		middleware(failing).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+		if rec.Code != http.StatusInternalServerError || got == nil {
+			t.Errorf("ServeHTTP() status = %d, error = %v, want %d and an error", rec.Code, got, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("claimed-elsewhere", func(t *testing.T) {
+		// Another receiver sharing the store holds the claim.
+		store := EventStoreFuncs{
+			SeenFunc:  func(_ context.Context, _ string) (bool, error) { return false, nil },
+			ClaimFunc: func(_ context.Context, _ string) (bool, error) { return false, nil },
+		}
+
+		var called bool
+		handler := DeduplicateWebhookEvents(store)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			called = true
+		}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+		if rec.Code != http.StatusServiceUnavailable || called {
+			t.Errorf("ServeHTTP() status = %d, called = %t, want %d", rec.Code, called, http.StatusServiceUnavailable)
+		}
+	})
+
+	t.Run("in-flight", func(t *testing.T) {
+		store := NewMemoryEventStore(0, 0)
+
+		var middleware http.Handler
+		var nested int
+
+		middleware = DeduplicateWebhookEvents(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Deliver the same event again while the first delivery is processed.
+			rec := httptest.NewRecorder()
+			middleware.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+			nested = rec.Code
+
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		rec := httptest.NewRecorder()
+		middleware.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+		if rec.Code != http.StatusOK || nested != http.StatusServiceUnavailable {
+			t.Errorf("ServeHTTP() status = %d, nested = %d, want %d and %d", rec.Code, nested, http.StatusOK, http.StatusServiceUnavailable)
+		}
+	})
+
+	t.Run("verifier", func(t *testing.T) {
+		now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+		ts := strconv.FormatInt(now.Unix(), 10)
+
+		v := NewWebhookVerifier(WithWebhookSecret("key"))
+		v.now = func() time.Time { return now }
+
+		var calls int
+		store := EventStoreFuncs{
+			SeenFunc:    func(_ context.Context, _ string) (bool, error) { calls++; return false, nil },
+			ClaimFunc:   func(_ context.Context, _ string) (bool, error) { calls++; return true, nil },
+			ReleaseFunc: func(_ context.Context, _ string) error { calls++; return nil },
+			MarkFunc:    func(_ context.Context, _ string) error { calls++; return nil },
+		}
+		handler := DeduplicateWebhookEvents(store, WithDeduplicateVerifier(v))(next)
+
+		deliver := func(signature string) int {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			r.Header.Set(DefaultWebhookSignatureHeader, signature)
+			r.Header.Set(DefaultWebhookTimestampHeader, ts)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+
+			return rec.Code
+		}
+
+		// A forged request is rejected before the store is used.
+		if got := deliver(sign(t, "guess", ts+"."+body)); got != http.StatusUnauthorized || calls != 0 {
+			t.Errorf("ServeHTTP() forged status = %d, store calls = %d, want %d and 0", got, calls, http.StatusUnauthorized)
+		}
+
+		if got := deliver(sign(t, "key", ts+"."+body)); got != http.StatusOK || calls != 2 {
+			t.Errorf("ServeHTTP() status = %d, store calls = %d, want %d and 2", got, calls, http.StatusOK)
+		}
+
+		// The processed request was recorded by the verifier, so a replay is rejected.
+		if got := deliver(sign(t, "key", ts+"."+body)); got != http.StatusUnauthorized || calls != 2 {
+			t.Errorf("ServeHTTP() replay status = %d, store calls = %d, want %d and 2", got, calls, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("key", func(t *testing.T) {
+		store := NewMemoryEventStore(0, 0)
+		middleware := DeduplicateWebhookEvents(store, WithEventKey(func(event *WebhookEvent) string {
+			return "custom:" + event.Id
+		}))
+
+		rec := httptest.NewRecorder()
+		middleware(next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+		seen, _ := store.Seen(context.Background(), "custom:e1")
+		if !seen {
+			t.Errorf("Seen(custom:e1) = false, want true")
+		}
+	})
+}
+
+func TestMemoryEventStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	store := NewMemoryEventStore(2, time.Minute)
+	store.now = func() time.Time { return now }
+
+	_ = store.Mark(ctx, "a")
+	_ = store.Mark(ctx, "b")
+
+	// Using "a" makes "b" the least recently used key, which is removed by marking "c".
+	if seen, _ := store.Seen(ctx, "a"); !seen {
+		t.Errorf("Seen(a) = false, want true")
+	}
+
+	_ = store.Mark(ctx, "c")
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true, "d": false} {
+		if seen, _ := store.Seen(ctx, key); seen != want {
+			t.Errorf("Seen(%s) = %t, want %t", key, seen, want)
+		}
+	}
+
+	now = now.Add(time.Minute)
+
+	if seen, _ := store.Seen(ctx, "a"); seen {
+		t.Errorf("Seen(a) = true after the TTL, want false")
+	}
+
+	_ = store.Mark(ctx, "d")
+
+	if store.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", store.Len())
+	}
+}
+
+func TestEventStore_Claim(t *testing.T) {
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "bonusly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fileStore, err := NewFileEventStore(filepath.Join(dir, "events"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileEventStore() error = %v", err)
+	}
+	defer fileStore.Close()
+
+	stores := map[string]EventStore{
+		"memory": NewMemoryEventStore(10, time.Hour),
+		"file":   fileStore,
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			steps := []struct {
+				op   string
+				want bool
+			}{
+				{"claim", true},
+				{"claim", false},
+				{"release", false},
+				{"claim", true},
+				{"mark", false},
+				{"claim", false},
+			}
+			for i, step := range steps {
+				var got bool
+				var err error
+
+				switch step.op {
+				case "claim":
+					got, err = store.Claim(ctx, "a")
+				case "release":
+					err = store.Release(ctx, "a")
+				case "mark":
+					err = store.Mark(ctx, "a")
+				}
+
+				if err != nil || got != step.want {
+					t.Errorf("step %d: %s() = %t, %v, want %t", i, step.op, got, err, step.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFileEventStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	dir, err := ioutil.TempDir("", "bonusly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events")
+
+	store, err := NewFileEventStore(path, time.Minute)
+	if err != nil {
+		t.Fatalf("NewFileEventStore() error = %v", err)
+	}
+	store.now = func() time.Time { return now }
+
+	_ = store.Mark(ctx, "a")
+	now = now.Add(30 * time.Second)
+	_ = store.Mark(ctx, "b")
+
+	err = store.Close()
+	if err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Simulate a line that was only partially written before a crash.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"key":"c","exp`)
+	_ = f.Close()
+
+	// Reopen the store after "a" expired. The timestamps of the file are in 2022, so the store must use the same clock.
+	store = &FileEventStore{path: path, ttl: time.Minute, now: func() time.Time { return now.Add(45 * time.Second) }, keys: map[string]time.Time{}, claims: map[string]struct{}{}}
+
+	err = store.load()
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	defer store.Close()
+
+	for key, want := range map[string]bool{"a": false, "b": true, "c": false} {
+		if seen, _ := store.Seen(ctx, key); seen != want {
+			t.Errorf("Seen(%s) = %t, want %t", key, seen, want)
+		}
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Count(string(b), "\n"); got != 1 || !strings.Contains(string(b), `"key":"b"`) {
+		t.Errorf("file = %s, want only key b", b)
+	}
+}
+
+func TestFileEventStore_Compact(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	dir, err := ioutil.TempDir("", "bonusly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events")
+
+	store, err := NewFileEventStore(path, time.Minute)
+	if err != nil {
+		t.Fatalf("NewFileEventStore() error = %v", err)
+	}
+	defer store.Close()
+
+	store.now = func() time.Time { return now }
+	store.compactInterval = 2
+
+	lines := func() int {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return strings.Count(string(b), "\n")
+	}
+
+	_ = store.Mark(ctx, "a")
+	_ = store.Mark(ctx, "b")
+
+	// Nothing expired, so the file is kept.
+	if got := lines(); got != 2 {
+		t.Errorf("lines = %d, want 2", got)
+	}
+
+	now = now.Add(time.Minute)
+
+	_ = store.Mark(ctx, "c")
+	_ = store.Mark(ctx, "d")
+
+	// "a" and "b" expired, which are half of the lines, so the file is compacted.
+	if got := lines(); got != 2 || len(store.keys) != 2 {
+		t.Errorf("lines = %d, keys = %d, want 2 and 2", got, len(store.keys))
+	}
+
+	// The compacted file is still used for appending.
+	_ = store.Mark(ctx, "e")
+
+	if got := lines(); got != 3 {
+		t.Errorf("lines = %d, want 3", got)
+	}
+
+	for key, want := range map[string]bool{"a": false, "c": true, "d": true, "e": true} {
+		if seen, _ := store.Seen(ctx, key); seen != want {
+			t.Errorf("Seen(%s) = %t, want %t", key, seen, want)
+		}
+	}
+}
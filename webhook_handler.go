@@ -0,0 +1,203 @@
+package bonusly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// maxWebhookBodySize is the maximum size of the body of a webhook request in bytes.
+const maxWebhookBodySize = 1 << 20
+
+// WebhookEvent is the body of a webhook request sent by Bonus.ly. The Data depends on the Type of the event.
+type WebhookEvent struct {
+	// Id of the event.
+	Id string `json:"id"`
+	// Type of the event.
+	Type WebhookEventType `json:"type"`
+	// CreatedAt is the time the event was created.
+	CreatedAt time.Time `json:"created_at"`
+	// Data is the raw resource the event refers to, for example the created bonus.
+	Data json.RawMessage `json:"data"`
+}
+
+// BonusCreatedEvent is sent by Bonus.ly if a bonus was created (WebhookEventTypeBonusCreated).
+type BonusCreatedEvent struct {
+	// Id of the event.
+	Id string
+	// CreatedAt is the time the event was created.
+	CreatedAt time.Time
+	// Bonus is the created bonus.
+	Bonus Bonus
+}
+
+// AchievementEventCreatedEvent is sent by Bonus.ly if an achievement was awarded to a user
+// (WebhookEventTypeAchievementEventCreated).
+type AchievementEventCreatedEvent struct {
+	// Id of the event.
+	Id string
+	// CreatedAt is the time the event was created.
+	CreatedAt time.Time
+	// Achievement is the awarded achievement.
+	Achievement Achievement
+}
+
+// WebhookHandlerOption is a functional option to configure the WebhookHandler.
+type WebhookHandlerOption func(h *WebhookHandler)
+
+// OnBonusCreated registers the callback that is called for every BonusCreatedEvent.
+func OnBonusCreated(fn func(ctx context.Context, event *BonusCreatedEvent) error) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.onBonusCreated = fn
+	}
+}
+
+// OnAchievementEventCreated registers the callback that is called for every AchievementEventCreatedEvent.
+func OnAchievementEventCreated(fn func(ctx context.Context, event *AchievementEventCreatedEvent) error) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.onAchievementEventCreated = fn
+	}
+}
+
+// WithWebhookVerifier sets the WebhookVerifier used to verify that requests were sent by Bonus.ly. Requests failing the
+// verification are rejected with 401 (Unauthorized) before any callback is called. Requests that were processed
+// successfully are recorded by the verifier, so replays of them are rejected.
+func WithWebhookVerifier(verifier *WebhookVerifier) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.verifier = verifier
+	}
+}
+
+// WebhookHandler is an http.Handler that receives the webhook requests sent by Bonus.ly and dispatches the events to
+// the registered callbacks.
+//
+// Use NewWebhookHandler to create a new WebhookHandler.
+type WebhookHandler struct {
+	onBonusCreated            func(ctx context.Context, event *BonusCreatedEvent) error
+	onAchievementEventCreated func(ctx context.Context, event *AchievementEventCreatedEvent) error
+	verifier                  *WebhookVerifier
+}
+
+// NewWebhookHandler returns a new WebhookHandler that dispatches the events to the callbacks registered with the
+// options, for example OnBonusCreated.
+//
+// The handler responds to Bonus.ly as follows:
+//   - 200 (OK) if the event was processed by the callback, or no callback is registered for the event type.
+//   - 400 (Bad Request) if the body is not a valid event. Redelivering the event would fail again.
+//   - 401 (Unauthorized) if a WebhookVerifier is set and the request fails the verification.
+//   - 405 (Method Not Allowed) if the request is not a POST request.
+//   - 500 (Internal Server Error) if the callback returned an error, so Bonus.ly redelivers the event later.
+func NewWebhookHandler(options ...WebhookHandlerOption) *WebhookHandler {
+	h := &WebhookHandler{}
+
+	for _, fn := range options {
+		fn(h)
+	}
+
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.verifier != nil {
+		err := h.verifier.VerifyWebhookRequest(r)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+
+	event, err := ParseWebhookEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.dispatch(r.Context(), event)
+
+	var invalid *invalidWebhookEventError
+	switch {
+	case errors.As(err, &invalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err != nil:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	default:
+		if h.verifier != nil {
+			h.verifier.RecordWebhookRequest(r)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// dispatch decodes the data of the event and calls the callback registered for the event type. Events without a
+// callback are ignored.
+func (h *WebhookHandler) dispatch(ctx context.Context, event *WebhookEvent) error {
+	switch {
+	case event.Type == WebhookEventTypeBonusCreated && h.onBonusCreated != nil:
+		e := &BonusCreatedEvent{Id: event.Id, CreatedAt: event.CreatedAt}
+
+		err := json.Unmarshal(event.Data, &e.Bonus)
+		if err != nil {
+			return &invalidWebhookEventError{err: err}
+		}
+
+		return h.onBonusCreated(ctx, e)
+	case event.Type == WebhookEventTypeAchievementEventCreated && h.onAchievementEventCreated != nil:
+		e := &AchievementEventCreatedEvent{Id: event.Id, CreatedAt: event.CreatedAt}
+
+		err := json.Unmarshal(event.Data, &e.Achievement)
+		if err != nil {
+			return &invalidWebhookEventError{err: err}
+		}
+
+		return h.onAchievementEventCreated(ctx, e)
+	default:
+		return nil
+	}
+}
+
+// ParseWebhookEvent parses the body of a webhook request sent by Bonus.ly. The Data of the event is not decoded.
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+
+	err := json.Unmarshal(body, &event)
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook event: %w", err)
+	}
+
+	if event.Type == "" {
+		return nil, fmt.Errorf("parse webhook event: missing type")
+	}
+
+	return &event, nil
+}
+
+// invalidWebhookEventError is returned by WebhookHandler.dispatch if the data of an event could not be decoded.
+type invalidWebhookEventError struct {
+	err error
+}
+
+func (e *invalidWebhookEventError) Error() string {
+	return fmt.Sprintf("invalid webhook event data: %v", e.err)
+}
+
+func (e *invalidWebhookEventError) Unwrap() error {
+	return e.err
+}
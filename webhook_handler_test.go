@@ -0,0 +1,74 @@
+package bonusly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	var bonuses []*BonusCreatedEvent
+	var achievements []*AchievementEventCreatedEvent
+
+	handler := NewWebhookHandler(
+		OnBonusCreated(func(_ context.Context, event *BonusCreatedEvent) error {
+			if event.Bonus.Reason == "fail" {
+				return errors.New("callback failed")
+			}
+
+			bonuses = append(bonuses, event)
+			return nil
+		}),
+		OnAchievementEventCreated(func(_ context.Context, event *AchievementEventCreatedEvent) error {
+			achievements = append(achievements, event)
+			return nil
+		}),
+	)
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"get", http.MethodGet, ``, http.StatusMethodNotAllowed},
+		{"invalid-json", http.MethodPost, `{`, http.StatusBadRequest},
+		{"missing-type", http.MethodPost, `{"id": "e1", "data": {}}`, http.StatusBadRequest},
+		{"invalid-data", http.MethodPost, `{"id": "e1", "type": "bonus.created", "data": {"amount": "ten"}}`, http.StatusBadRequest},
+		{"unknown-type", http.MethodPost, `{"id": "e1", "type": "bonus.deleted", "data": {}}`, http.StatusOK},
+		{"callback-error", http.MethodPost, `{"id": "e1", "type": "bonus.created", "data": {"id": "b1", "reason": "fail"}}`, http.StatusInternalServerError},
+		{
+			"bonus-created",
+			http.MethodPost,
+			`{"id": "e2", "type": "bonus.created", "created_at": "2022-03-01T10:00:00Z", "data": {"id": "b2", "amount": 10, "giver": {"email": "frodo@example.com"}}}`,
+			http.StatusOK,
+		},
+		{
+			"achievement-event-created",
+			http.MethodPost,
+			`{"id": "e3", "type": "achievement_event.created", "data": {"id": "a1", "title": "First Bonus", "user": {"id": "1"}}}`,
+			http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, "/webhooks/bonusly", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.want {
+				t.Errorf("ServeHTTP() status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+
+	if len(bonuses) != 1 || bonuses[0].Id != "e2" || bonuses[0].Bonus.Id != "b2" || bonuses[0].Bonus.Giver.Email != "frodo@example.com" || bonuses[0].CreatedAt.IsZero() {
+		t.Errorf("OnBonusCreated() events = %+v", bonuses)
+	}
+
+	if len(achievements) != 1 || achievements[0].Achievement.Title != "First Bonus" {
+		t.Errorf("OnAchievementEventCreated() events = %+v", achievements)
+	}
+}
@@ -0,0 +1,356 @@
+package bonusly
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidWebhookToken is returned by WebhookVerifier.VerifyWebhookRequest if the allowlist token of the
+	// request URL is missing or wrong.
+	ErrInvalidWebhookToken = errors.New("invalid webhook token")
+	// ErrInvalidWebhookSignature is returned by WebhookVerifier.VerifyWebhookRequest if the signature of the request is
+	// missing or wrong.
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	// ErrInvalidWebhookTimestamp is returned by WebhookVerifier.VerifyWebhookRequest if the timestamp of the request
+	// is invalid or outside the tolerance.
+	ErrInvalidWebhookTimestamp = errors.New("invalid webhook timestamp")
+	// ErrWebhookReplayed is returned by WebhookVerifier.VerifyWebhookRequest if the same signed request was already
+	// processed successfully before.
+	ErrWebhookReplayed = errors.New("webhook replayed")
+	// ErrWebhookVerifierNotConfigured is returned by WebhookVerifier.VerifyWebhookRequest if the verifier has neither
+	// a secret nor a token, so it can not tell requests sent by Bonus.ly from forged requests.
+	ErrWebhookVerifierNotConfigured = errors.New("webhook verifier has neither a secret nor a token")
+)
+
+const (
+	// DefaultWebhookSignatureHeader is the default name of the header containing the signature of a webhook request.
+	DefaultWebhookSignatureHeader = "X-Bonusly-Signature"
+	// DefaultWebhookTimestampHeader is the default name of the header containing the timestamp of a webhook request.
+	DefaultWebhookTimestampHeader = "X-Bonusly-Timestamp"
+	// DefaultWebhookTokenParameter is the default name of the query parameter containing the allowlist token.
+	DefaultWebhookTokenParameter = "token"
+	// DefaultWebhookTolerance is the default maximum difference between the timestamp of a webhook request and now.
+	DefaultWebhookTolerance = 5 * time.Minute
+	// DefaultWebhookReplayCacheSize is the default maximum number of processed requests remembered by the replay
+	// protection.
+	DefaultWebhookReplayCacheSize = 10000
+)
+
+// WebhookVerifierOption is a functional option to configure the WebhookVerifier.
+type WebhookVerifierOption func(v *WebhookVerifier)
+
+// WithWebhookSecret enables the verification of the HMAC-SHA256 signature of webhook requests using the shared
+// secret.
+func WithWebhookSecret(secret string) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.secret = []byte(secret)
+	}
+}
+
+// WithWebhookToken enables the verification of the allowlist token. The token must be embedded as query parameter in
+// the Webhook.URL registered with CreateWebhook, for example "https://example.com/webhooks/bonusly?token=<token>".
+func WithWebhookToken(token string) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.token = token
+	}
+}
+
+// WithWebhookTokenParameter sets the name of the query parameter containing the allowlist token.
+func WithWebhookTokenParameter(name string) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.tokenParameter = name
+	}
+}
+
+// WithWebhookSignatureHeader sets the name of the header containing the signature.
+func WithWebhookSignatureHeader(name string) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.signatureHeader = name
+	}
+}
+
+// WithWebhookTimestampHeader sets the name of the header containing the timestamp.
+func WithWebhookTimestampHeader(name string) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.timestampHeader = name
+	}
+}
+
+// WithWebhookTolerance sets the maximum difference between the timestamp of a webhook request and now. Requests with
+// a timestamp outside the tolerance are rejected, which limits how long a captured request can be replayed. A
+// tolerance of 0 disables the timestamp check and the replay protection.
+func WithWebhookTolerance(tolerance time.Duration) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.tolerance = tolerance
+	}
+}
+
+// WithWebhookTimestampRequired rejects requests without a timestamp header, so the timestamp is always part of the
+// signed message and the tolerance and replay protection can not be bypassed by removing the header.
+func WithWebhookTimestampRequired() WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.timestampRequired = true
+	}
+}
+
+// WithWebhookReplayCacheSize sets the maximum number of processed requests remembered by the replay protection. If
+// more requests are processed within the tolerance, the oldest requests are forgotten. A size of 0 disables the replay
+// protection.
+func WithWebhookReplayCacheSize(size int) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.replayCacheSize = size
+	}
+}
+
+// WebhookVerifier verifies that webhook requests were sent by Bonus.ly. It is safe for concurrent use.
+//
+// The verifier performs the following checks, depending on its options:
+//   - Token: The query parameter of the request URL must contain the allowlist token (WithWebhookToken).
+//   - Signature: The signature header must contain the hex-encoded HMAC-SHA256 of the body, keyed with the shared
+//     secret (WithWebhookSecret). If the request has a timestamp header, the signed message is
+//     "<timestamp>.<body>". An optional "sha256=" prefix of the signature is ignored.
+//   - Timestamp: If the request has a timestamp header (unix seconds), it must be within the tolerance. With
+//     WithWebhookTimestampRequired, requests without a timestamp header are rejected.
+//   - Replay: A signed request with a timestamp is rejected if the same request was processed successfully within
+//     the tolerance. The request is only remembered after RecordWebhookRequest was called, which the WebhookHandler
+//     does once the callback succeeded, so Bonus.ly can redeliver the request if processing failed.
+//
+// The replay protection does not cover concurrent deliveries of the same request. To process every event only once,
+// use DeduplicateWebhookEvents in addition.
+//
+// Use NewWebhookVerifier to create a new WebhookVerifier.
+type WebhookVerifier struct {
+	secret            []byte
+	token             string
+	tokenParameter    string
+	signatureHeader   string
+	timestampHeader   string
+	timestampRequired bool
+	tolerance         time.Duration
+	replayCacheSize   int
+	now               func() time.Time
+
+	mu sync.Mutex
+	// seen contains the keys of the processed requests, sorted by the time they expire from the replay protection.
+	seen     *list.List
+	seenKeys map[string]*list.Element
+}
+
+type webhookReplayEntry struct {
+	key     string
+	expires time.Time
+}
+
+// NewWebhookVerifier returns a new WebhookVerifier configured by the options.
+//
+// The verifier must be configured with a secret (WithWebhookSecret) or a token (WithWebhookToken). Otherwise, it can
+// not tell requests sent by Bonus.ly from forged requests and VerifyWebhookRequest rejects every request with
+// ErrWebhookVerifierNotConfigured.
+func NewWebhookVerifier(options ...WebhookVerifierOption) *WebhookVerifier {
+	v := &WebhookVerifier{
+		tokenParameter:  DefaultWebhookTokenParameter,
+		signatureHeader: DefaultWebhookSignatureHeader,
+		timestampHeader: DefaultWebhookTimestampHeader,
+		tolerance:       DefaultWebhookTolerance,
+		replayCacheSize: DefaultWebhookReplayCacheSize,
+		now:             time.Now,
+		seen:            list.New(),
+		seenKeys:        make(map[string]*list.Element),
+	}
+
+	for _, fn := range options {
+		fn(v)
+	}
+
+	return v
+}
+
+// VerifyWebhookRequest returns an error if the request was not sent by Bonus.ly, or if the request was already
+// processed successfully. The body of the request is read and replaced, so it can be read again afterwards.
+//
+// VerifyWebhookRequest does not remember the request. Call RecordWebhookRequest after the request was processed
+// successfully to reject replays of it.
+//nolint: cyclop
+func (v *WebhookVerifier) VerifyWebhookRequest(r *http.Request) error {
+	if len(v.secret) == 0 && v.token == "" {
+		return ErrWebhookVerifierNotConfigured
+	}
+
+	if v.token != "" {
+		token := r.URL.Query().Get(v.tokenParameter)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(v.token)) != 1 {
+			return ErrInvalidWebhookToken
+		}
+	}
+
+	timestamp := r.Header.Get(v.timestampHeader)
+	if timestamp == "" && v.timestampRequired {
+		return fmt.Errorf("%w: missing %s header", ErrInvalidWebhookTimestamp, v.timestampHeader)
+	}
+
+	if timestamp != "" {
+		err := v.checkTimestamp(timestamp)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(v.secret) == 0 {
+		return nil
+	}
+
+	body, err := readAndRestoreBody(r)
+	if err != nil {
+		return err
+	}
+
+	signature := strings.TrimPrefix(r.Header.Get(v.signatureHeader), "sha256=")
+	if !v.validSignature(signature, timestamp, body) {
+		return ErrInvalidWebhookSignature
+	}
+
+	if v.replayed(r) {
+		return ErrWebhookReplayed
+	}
+
+	return nil
+}
+
+// RecordWebhookRequest remembers a request that was verified by VerifyWebhookRequest and processed successfully, so
+// VerifyWebhookRequest rejects replays of it with ErrWebhookReplayed. Only signed requests with a timestamp are
+// remembered, for twice the tolerance.
+func (v *WebhookVerifier) RecordWebhookRequest(r *http.Request) {
+	key, ok := v.replayKey(r)
+	if !ok {
+		return
+	}
+
+	now := v.now()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.removeExpired(now)
+
+	if _, exists := v.seenKeys[key]; exists {
+		return
+	}
+
+	// A request is valid for the tolerance in both directions of its timestamp, so it has to be remembered for twice
+	// the tolerance.
+	v.seenKeys[key] = v.seen.PushBack(&webhookReplayEntry{key: key, expires: now.Add(2 * v.tolerance)})
+
+	for v.seen.Len() > v.replayCacheSize {
+		v.remove(v.seen.Front())
+	}
+}
+
+// replayed returns true if the request was recorded by RecordWebhookRequest and did not expire yet.
+func (v *WebhookVerifier) replayed(r *http.Request) bool {
+	key, ok := v.replayKey(r)
+	if !ok {
+		return false
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.removeExpired(v.now())
+
+	_, exists := v.seenKeys[key]
+
+	return exists
+}
+
+// replayKey returns the key of the request used by the replay protection, which consists of the signature and the
+// timestamp. If the replay protection does not apply to the request, false is returned.
+func (v *WebhookVerifier) replayKey(r *http.Request) (string, bool) {
+	timestamp := r.Header.Get(v.timestampHeader)
+	if len(v.secret) == 0 || timestamp == "" || v.tolerance <= 0 || v.replayCacheSize <= 0 {
+		return "", false
+	}
+
+	signature := strings.ToLower(strings.TrimPrefix(r.Header.Get(v.signatureHeader), "sha256="))
+
+	return signature + "." + timestamp, true
+}
+
+// removeExpired removes the requests that expired from the replay protection. The caller must hold v.mu.
+func (v *WebhookVerifier) removeExpired(now time.Time) {
+	for e := v.seen.Front(); e != nil && !now.Before(e.Value.(*webhookReplayEntry).expires); e = v.seen.Front() {
+		v.remove(e)
+	}
+}
+
+func (v *WebhookVerifier) remove(e *list.Element) {
+	v.seen.Remove(e)
+	delete(v.seenKeys, e.Value.(*webhookReplayEntry).key)
+}
+
+// checkTimestamp returns an error if the timestamp is not a unix timestamp within the tolerance.
+func (v *WebhookVerifier) checkTimestamp(timestamp string) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidWebhookTimestamp, timestamp)
+	}
+
+	diff := v.now().Sub(time.Unix(seconds, 0))
+	if diff < 0 {
+		diff = -diff
+	}
+
+	if v.tolerance > 0 && diff > v.tolerance {
+		return fmt.Errorf("%w: %s is outside the tolerance of %s", ErrInvalidWebhookTimestamp, timestamp, v.tolerance)
+	}
+
+	return nil
+}
+
+// validSignature returns true if the signature is the hex-encoded HMAC-SHA256 of the signed message.
+func (v *WebhookVerifier) validSignature(signature string, timestamp string, body []byte) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	if timestamp != "" {
+		mac.Write([]byte(timestamp + "."))
+	}
+	mac.Write(body)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// readAndRestoreBody reads the body of the request and replaces it with a reader of the read bytes.
+func readAndRestoreBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxWebhookBodySize))
+	cerr := r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
@@ -0,0 +1,273 @@
+package bonusly
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sign(t *testing.T, secret string, message string) string {
+	t.Helper()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookVerifier_VerifyWebhookRequest(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	old := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+	body := `{"id": "e1", "type": "bonus.created", "data": {}}`
+
+	tests := []struct {
+		name    string
+		options []WebhookVerifierOption
+		url     string
+		headers map[string]string
+		want    error
+	}{
+		{"not-configured", nil, "/webhooks", nil, ErrWebhookVerifierNotConfigured},
+		{"token", []WebhookVerifierOption{WithWebhookToken("s3cret")}, "/webhooks?token=s3cret", nil, nil},
+		{"token-wrong", []WebhookVerifierOption{WithWebhookToken("s3cret")}, "/webhooks?token=guess", nil, ErrInvalidWebhookToken},
+		{"token-missing", []WebhookVerifierOption{WithWebhookToken("s3cret")}, "/webhooks", nil, ErrInvalidWebhookToken},
+		{
+			"token-parameter",
+			[]WebhookVerifierOption{WithWebhookToken("s3cret"), WithWebhookTokenParameter("key")},
+			"/webhooks?key=s3cret",
+			nil,
+			nil,
+		},
+		{
+			"signature",
+			[]WebhookVerifierOption{WithWebhookSecret("key")},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: sign(t, "key", body)},
+			nil,
+		},
+		{
+			"signature-prefix",
+			[]WebhookVerifierOption{WithWebhookSecret("key")},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: "sha256=" + sign(t, "key", body)},
+			nil,
+		},
+		{
+			"signature-wrong-secret",
+			[]WebhookVerifierOption{WithWebhookSecret("key")},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: sign(t, "other", body)},
+			ErrInvalidWebhookSignature,
+		},
+		{"signature-missing", []WebhookVerifierOption{WithWebhookSecret("key")}, "/webhooks", nil, ErrInvalidWebhookSignature},
+		{
+			"signature-timestamp",
+			[]WebhookVerifierOption{WithWebhookSecret("key")},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: sign(t, "key", ts+"."+body), DefaultWebhookTimestampHeader: ts},
+			nil,
+		},
+		{
+			"signature-timestamp-tampered",
+			[]WebhookVerifierOption{WithWebhookSecret("key")},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: sign(t, "key", ts+"."+body), DefaultWebhookTimestampHeader: strconv.FormatInt(now.Unix()-1, 10)},
+			ErrInvalidWebhookSignature,
+		},
+		{
+			"timestamp-expired",
+			[]WebhookVerifierOption{WithWebhookSecret("key")},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: sign(t, "key", old+"."+body), DefaultWebhookTimestampHeader: old},
+			ErrInvalidWebhookTimestamp,
+		},
+		{
+			"timestamp-tolerance",
+			[]WebhookVerifierOption{WithWebhookSecret("key"), WithWebhookTolerance(time.Hour)},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: sign(t, "key", old+"."+body), DefaultWebhookTimestampHeader: old},
+			nil,
+		},
+		{
+			"timestamp-invalid",
+			[]WebhookVerifierOption{WithWebhookToken("s3cret")},
+			"/webhooks?token=s3cret",
+			map[string]string{DefaultWebhookTimestampHeader: "yesterday"},
+			ErrInvalidWebhookTimestamp,
+		},
+		{
+			"timestamp-required",
+			[]WebhookVerifierOption{WithWebhookSecret("key"), WithWebhookTimestampRequired()},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: sign(t, "key", ts+"."+body), DefaultWebhookTimestampHeader: ts},
+			nil,
+		},
+		{
+			"timestamp-required-missing",
+			[]WebhookVerifierOption{WithWebhookSecret("key"), WithWebhookTimestampRequired()},
+			"/webhooks",
+			map[string]string{DefaultWebhookSignatureHeader: sign(t, "key", body)},
+			ErrInvalidWebhookTimestamp,
+		},
+		{
+			"custom-headers",
+			[]WebhookVerifierOption{WithWebhookSecret("key"), WithWebhookSignatureHeader("X-Sig"), WithWebhookTimestampHeader("X-Time")},
+			"/webhooks",
+			map[string]string{"X-Sig": sign(t, "key", ts+"."+body), "X-Time": ts},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewWebhookVerifier(tt.options...)
+			v.now = func() time.Time { return now }
+
+			r := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(body))
+			for k, val := range tt.headers {
+				r.Header.Set(k, val)
+			}
+
+			err := v.VerifyWebhookRequest(r)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyWebhookRequest() error = %v, want %v", err, tt.want)
+			}
+
+			b, _ := ioutil.ReadAll(r.Body)
+			if string(b) != body {
+				t.Errorf("VerifyWebhookRequest() body after verification = %s, want %s", b, body)
+			}
+		})
+	}
+}
+
+func TestWebhookVerifier_Replay(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	body := `{"id": "e1", "type": "bonus.created", "data": {}}`
+
+	v := NewWebhookVerifier(WithWebhookSecret("key"), WithWebhookReplayCacheSize(2))
+	v.now = func() time.Time { return now }
+
+	request := func(ts string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		r.Header.Set(DefaultWebhookSignatureHeader, sign(t, "key", ts+"."+body))
+		r.Header.Set(DefaultWebhookTimestampHeader, ts)
+		return r
+	}
+
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	if err := v.VerifyWebhookRequest(request(ts)); err != nil {
+		t.Fatalf("VerifyWebhookRequest() error = %v", err)
+	}
+
+	// The request is only rejected after it was recorded.
+	if err := v.VerifyWebhookRequest(request(ts)); err != nil {
+		t.Fatalf("VerifyWebhookRequest() before recording error = %v", err)
+	}
+
+	v.RecordWebhookRequest(request(ts))
+
+	if err := v.VerifyWebhookRequest(request(ts)); !errors.Is(err, ErrWebhookReplayed) {
+		t.Errorf("VerifyWebhookRequest() replay error = %v, want %v", err, ErrWebhookReplayed)
+	}
+
+	// Recording more requests than the cache size forgets the oldest request.
+	for i := 1; i <= 2; i++ {
+		v.RecordWebhookRequest(request(strconv.FormatInt(now.Unix()+int64(i), 10)))
+	}
+
+	if err := v.VerifyWebhookRequest(request(ts)); err != nil {
+		t.Errorf("VerifyWebhookRequest() after eviction error = %v", err)
+	}
+
+	// Requests are forgotten after twice the tolerance, when their timestamp is rejected anyway.
+	now = now.Add(2 * DefaultWebhookTolerance)
+	v.RecordWebhookRequest(request(strconv.FormatInt(now.Unix(), 10)))
+
+	if len(v.seenKeys) != 1 || v.seen.Len() != 1 {
+		t.Errorf("seen = %d, want 1", len(v.seenKeys))
+	}
+}
+
+func TestWebhookHandler_VerifierRedelivery(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	body := `{"id": "e1", "type": "bonus.created", "data": {"id": "b1"}}`
+
+	v := NewWebhookVerifier(WithWebhookSecret("key"))
+	v.now = func() time.Time { return now }
+
+	calls := 0
+	handler := NewWebhookHandler(
+		WithWebhookVerifier(v),
+		OnBonusCreated(func(_ context.Context, _ *BonusCreatedEvent) error {
+			calls++
+			if calls == 1 {
+				return errors.New("callback failed")
+			}
+
+			return nil
+		}),
+	)
+
+	deliver := func() int {
+		r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		r.Header.Set(DefaultWebhookSignatureHeader, sign(t, "key", ts+"."+body))
+		r.Header.Set(DefaultWebhookTimestampHeader, ts)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+
+		return rec.Code
+	}
+
+	if got := deliver(); got != http.StatusInternalServerError {
+		t.Errorf("ServeHTTP() status = %d, want %d", got, http.StatusInternalServerError)
+	}
+
+	// Bonus.ly redelivers the identical signed request after the failure.
+	if got := deliver(); got != http.StatusOK || calls != 2 {
+		t.Errorf("ServeHTTP() redelivery status = %d, calls = %d, want %d and 2", got, calls, http.StatusOK)
+	}
+
+	// A replay of the processed request is rejected.
+	if got := deliver(); got != http.StatusUnauthorized || calls != 2 {
+		t.Errorf("ServeHTTP() replay status = %d, calls = %d, want %d and 2", got, calls, http.StatusUnauthorized)
+	}
+}
+
+func TestWebhookHandler_Verifier(t *testing.T) {
+	var called bool
+
+	handler := NewWebhookHandler(
+		WithWebhookVerifier(NewWebhookVerifier(WithWebhookToken("s3cret"))),
+		OnBonusCreated(func(_ context.Context, _ *BonusCreatedEvent) error {
+			called = true
+			return nil
+		}),
+	)
+
+	body := `{"id": "e1", "type": "bonus.created", "data": {"id": "b1"}}`
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks?token=guess", strings.NewReader(body)))
+	if rec.Code != http.StatusUnauthorized || called {
+		t.Errorf("ServeHTTP() forged status = %d, called = %v", rec.Code, called)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks?token=s3cret", strings.NewReader(body)))
+	if rec.Code != http.StatusOK || !called {
+		t.Errorf("ServeHTTP() status = %d, called = %v", rec.Code, called)
+	}
+}
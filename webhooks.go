@@ -76,11 +76,6 @@ func (c *Client) ListWebhooks(ctx context.Context) (*ListWebhooksOutput, error)
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
-	if err != nil {
-		return nil, err
-	}
-
 	type response struct {
 		baseAPIResponse
 
@@ -88,15 +83,11 @@ func (c *Client) ListWebhooks(ctx context.Context) (*ListWebhooksOutput, error)
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("list webhooks", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("list webhooks: %v", r.Message)
-	}
-
 	return &ListWebhooksOutput{Webhooks: r.Result}, nil
 }
 
@@ -144,11 +135,6 @@ func (c *Client) CreateWebhook(ctx context.Context, params *CreateWebhookInput)
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
-	if err != nil {
-		return nil, err
-	}
-
 	type response struct {
 		baseAPIResponse
 
@@ -158,15 +144,11 @@ func (c *Client) CreateWebhook(ctx context.Context, params *CreateWebhookInput)
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("create webhook", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("create webhook: %v", r.Message)
-	}
-
 	return &CreateWebhookOutput{ID: r.Result.Id}, nil
 }
 
@@ -227,11 +209,6 @@ func (c *Client) UpdateWebhook(ctx context.Context, params *UpdateWebhookInput)
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
-	if err != nil {
-		return nil, err
-	}
-
 	type response struct {
 		baseAPIResponse
 
@@ -241,15 +218,11 @@ func (c *Client) UpdateWebhook(ctx context.Context, params *UpdateWebhookInput)
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("update webhook", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("create webhook: %v", r.Message)
-	}
-
 	return &UpdateWebhookOutput{ID: r.Result.Id}, nil
 }
 
@@ -282,11 +255,6 @@ func (c *Client) DeleteWebhook(ctx context.Context, params *DeleteWebhookInput)
 		return nil, err
 	}
 
-	body, err := readAndCloseBody(resp)
-	if err != nil {
-		return nil, err
-	}
-
 	type response struct {
 		baseAPIResponse
 
@@ -296,14 +264,10 @@ func (c *Client) DeleteWebhook(ctx context.Context, params *DeleteWebhookInput)
 	}
 
 	var r response
-	err = json.Unmarshal(body, &r)
+	err = decodeResponse("delete webhook", resp, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.Success {
-		return nil, fmt.Errorf("create webhook: %v", r.Message)
-	}
-
 	return &DeleteWebhookOutput{ID: r.Result.Id}, nil
 }